@@ -0,0 +1,18 @@
+action "coolify_application_restart" "app" {
+  config {
+    uuid    = "abc123"
+    timeout = "15m"
+  }
+}
+
+# Restart the application whenever its environment variables change
+resource "terraform_data" "env_revision" {
+  input = coolify_application_envs.example.id
+
+  lifecycle {
+    action_trigger {
+      events  = [after_update]
+      actions = [action.coolify_application_restart.app]
+    }
+  }
+}
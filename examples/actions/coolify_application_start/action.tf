@@ -0,0 +1,6 @@
+# Invoke with: terraform apply -invoke=action.coolify_application_start.example
+action "coolify_application_start" "example" {
+  config {
+    uuid = "abc123"
+  }
+}
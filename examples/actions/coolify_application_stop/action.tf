@@ -0,0 +1,6 @@
+# Invoke with: terraform apply -invoke=action.coolify_application_stop.example
+action "coolify_application_stop" "example" {
+  config {
+    uuid = "abc123"
+  }
+}
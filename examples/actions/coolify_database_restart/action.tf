@@ -0,0 +1,6 @@
+# Invoke with: terraform apply -invoke=action.coolify_database_restart.example
+action "coolify_database_restart" "example" {
+  config {
+    uuid = "abc123"
+  }
+}
@@ -0,0 +1,6 @@
+# Invoke with: terraform apply -invoke=action.coolify_database_start.example
+action "coolify_database_start" "example" {
+  config {
+    uuid = "abc123"
+  }
+}
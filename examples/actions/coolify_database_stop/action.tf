@@ -0,0 +1,6 @@
+# Invoke with: terraform apply -invoke=action.coolify_database_stop.staging
+action "coolify_database_stop" "staging" {
+  config {
+    uuid = "abc123"
+  }
+}
@@ -0,0 +1,8 @@
+# Invoke with: terraform apply -invoke=action.coolify_deploy.production
+action "coolify_deploy" "production" {
+  config {
+    tag     = "production"
+    force   = true
+    timeout = "30m"
+  }
+}
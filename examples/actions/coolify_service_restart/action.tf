@@ -0,0 +1,6 @@
+# Invoke with: terraform apply -invoke=action.coolify_service_restart.example
+action "coolify_service_restart" "example" {
+  config {
+    uuid = "abc123"
+  }
+}
@@ -0,0 +1,16 @@
+action "coolify_service_start" "app" {
+  config {
+    uuid = coolify_service.example.uuid
+  }
+}
+
+resource "terraform_data" "service" {
+  input = coolify_service.example.uuid
+
+  lifecycle {
+    action_trigger {
+      events  = [after_create]
+      actions = [action.coolify_service_start.app]
+    }
+  }
+}
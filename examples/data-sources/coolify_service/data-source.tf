@@ -2,3 +2,8 @@
 data "coolify_service" "example" {
   uuid = "abc123"
 }
+
+# Reference the generated URL of the `web` compose service
+output "web_url" {
+  value = data.coolify_service.example.compose_services["web"].fqdns[0]
+}
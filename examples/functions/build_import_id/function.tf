@@ -0,0 +1,4 @@
+import {
+  to = coolify_service.example
+  id = provider::coolify::build_import_id("rg8ks8c", "uoswco88w8swo40k48o8kcwk", "production", "joscs8wg0owkggsc4gwkw84k")
+}
@@ -0,0 +1,10 @@
+locals {
+  compose = file("${path.module}/docker-compose.yml")
+}
+
+output "service_uuids" {
+  value = {
+    for name in provider::coolify::compose_services(local.compose) :
+    name => coolify_service.example.compose_services[name].uuid
+  }
+}
@@ -0,0 +1,8 @@
+locals {
+  env = provider::coolify::parse_dotenv(file("${path.module}/.env"))
+}
+
+output "database_url" {
+  value     = local.env["DATABASE_URL"]
+  sensitive = true
+}
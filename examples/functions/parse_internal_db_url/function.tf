@@ -0,0 +1,8 @@
+locals {
+  # internal_db_url is sensitive, the parsed parts do not include the password
+  db = nonsensitive(provider::coolify::parse_internal_db_url(coolify_postgresql_database.example.internal_db_url))
+}
+
+output "database_host" {
+  value = "${local.db.host}:${local.db.port}"
+}
@@ -0,0 +1,8 @@
+variable "server_uuid" {
+  type = string
+
+  validation {
+    condition     = provider::coolify::validate_uuid(var.server_uuid)
+    error_message = "The server UUID must be a Coolify UUID, e.g. \"rg8ks8c\"."
+  }
+}
@@ -0,0 +1,14 @@
+list "coolify_mysql_database" "all" {
+  provider = coolify
+}
+
+list "coolify_mysql_database" "production" {
+  provider = coolify
+
+  config {
+    filter {
+      name   = "environment_name"
+      values = ["production"]
+    }
+  }
+}
@@ -0,0 +1,14 @@
+list "coolify_postgresql_database" "all" {
+  provider = coolify
+}
+
+list "coolify_postgresql_database" "production" {
+  provider = coolify
+
+  config {
+    filter {
+      name   = "environment_name"
+      values = ["production"]
+    }
+  }
+}
@@ -0,0 +1,14 @@
+list "coolify_private_key" "all" {
+  provider = coolify
+}
+
+list "coolify_private_key" "git" {
+  provider = coolify
+
+  config {
+    filter {
+      name   = "is_git_related"
+      values = ["true"]
+    }
+  }
+}
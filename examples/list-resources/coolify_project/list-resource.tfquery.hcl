@@ -0,0 +1,17 @@
+list "coolify_project" "all" {
+  provider = coolify
+
+  # Include the full resource to generate its configuration
+  include_resource = true
+}
+
+list "coolify_project" "filtered" {
+  provider = coolify
+
+  config {
+    filter {
+      name   = "name"
+      values = ["My Project"]
+    }
+  }
+}
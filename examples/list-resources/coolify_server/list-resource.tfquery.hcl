@@ -0,0 +1,14 @@
+list "coolify_server" "all" {
+  provider = coolify
+}
+
+list "coolify_server" "filtered" {
+  provider = coolify
+
+  config {
+    filter {
+      name   = "ip"
+      values = ["192.168.1.10"]
+    }
+  }
+}
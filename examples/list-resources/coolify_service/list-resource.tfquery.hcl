@@ -0,0 +1,14 @@
+list "coolify_service" "all" {
+  provider = coolify
+}
+
+list "coolify_service" "filtered" {
+  provider = coolify
+
+  config {
+    filter {
+      name   = "name"
+      values = ["whoami"]
+    }
+  }
+}
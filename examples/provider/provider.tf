@@ -15,6 +15,28 @@ provider "coolify" {
   token = "Your API token"
 }
 
+# Alternatively, read the endpoint and token from a Coolify CLI context.
+# provider "coolify" {
+#   context = "production"
+# }
+
+# Resources that do not set their own server, project or environment are
+# placed using the provider defaults.
+# provider "coolify" {
+#   defaults = {
+#     server_uuid      = "rg8ks8c"
+#     project_uuid     = "uoswco88oc8kacwgs8c4oswk"
+#     environment_name = "production"
+#   }
+# }
+
+# Resources setting `team_id` are managed with the token of that team.
+# provider "coolify" {
+#   team_tokens = {
+#     "3" = var.platform_team_token
+#   }
+# }
+
 # Generate a new private key, and create a server with that key.
 
 resource "tls_private_key" "example" {
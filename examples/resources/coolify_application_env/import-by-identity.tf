@@ -0,0 +1,17 @@
+import {
+  to = coolify_application_env.example
+  identity = {
+    application_uuid = "<application_uuid>"
+    key              = "<key>"
+  }
+}
+
+# Preview deployment variables
+import {
+  to = coolify_application_env.example_preview
+  identity = {
+    application_uuid = "<application_uuid>"
+    key              = "<key>"
+    is_preview       = true
+  }
+}
@@ -0,0 +1,7 @@
+terraform import coolify_application_env.example <application_uuid>/<key>
+
+# Preview deployment variables
+terraform import coolify_application_env.example_preview <application_uuid>/<key>/preview
+
+# Resources of another team are imported with its token from the provider `team_tokens` by appending the team ID
+terraform import coolify_application_env.example <application_uuid>/<key>/<team_id>
@@ -0,0 +1,6 @@
+import {
+  to = coolify_application_envs.example
+  identity = {
+    uuid = "<application_uuid>"
+  }
+}
@@ -2,6 +2,12 @@ resource "coolify_application_envs" "example" {
   # Application UUID
   uuid = "mc8gw00wscww4gskgk0gwgw0"
 
+  # Delete any variable not declared below, e.g. ones added through the UI
+  exclusive = true
+
+  # Load variables from a dotenv file, `env` blocks take precedence
+  dotenv = file("${path.module}/.env.production")
+
   env {
     key   = "key1"
     value = "value1"
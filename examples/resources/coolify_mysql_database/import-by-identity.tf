@@ -0,0 +1,9 @@
+import {
+  to = coolify_mysql_database.example
+  identity = {
+    server_uuid      = "<server_uuid>"
+    project_uuid     = "<project_uuid>"
+    environment_name = "<environment_name>"
+    uuid             = "<database_uuid>"
+  }
+}
@@ -1 +1,4 @@
-terraform import coolify_postgresql_database.example <server_uuid>/<project_uuid>/<environment_name>/<database_uuid>
\ No newline at end of file
+terraform import coolify_postgresql_database.example <server_uuid>/<project_uuid>/<environment_name>/<database_uuid>
+
+# Resources of another team are imported with its token from the provider `team_tokens` by appending the team ID
+terraform import coolify_postgresql_database.example <server_uuid>/<project_uuid>/<environment_name>/<database_uuid>/<team_id>
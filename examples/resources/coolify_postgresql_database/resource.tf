@@ -12,4 +12,32 @@ resource "coolify_postgresql_database" "example" {
   postgres_password = "hunter12"
 
   instant_deploy = false
+
+  # Refuse to destroy (or replace) the database until this is set to false
+  deletion_protection = true
+
+  # Keep the data volume if the database is ever destroyed
+  delete_options {
+    keep_volumes = true
+  }
+}
+
+# Keep the password out of state by using the write-only attribute (Terraform 1.11+).
+# Bump `postgres_password_wo_version` to rotate the password.
+# `internal_db_url` then leaves out the password as well.
+ephemeral "random_password" "postgres" {
+  length = 32
+}
+
+resource "coolify_postgresql_database" "write_only" {
+  name = "Example Write-Only Password Database"
+
+  server_uuid      = "rg8ks8c"
+  project_uuid     = "uoswco88w8swo40k48o8kcwk"
+  environment_name = "production"
+
+  postgres_db                  = "my_database"
+  postgres_user                = "postgres"
+  postgres_password_wo         = ephemeral.random_password.postgres.result
+  postgres_password_wo_version = 1
 }
@@ -0,0 +1,6 @@
+import {
+  to = coolify_private_key.example
+  identity = {
+    uuid = "<uuid>"
+  }
+}
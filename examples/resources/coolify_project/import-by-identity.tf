@@ -0,0 +1,6 @@
+import {
+  to = coolify_project.example
+  identity = {
+    uuid = "<uuid>"
+  }
+}
@@ -1 +1,4 @@
-terraform import coolify_project.example <uuid>
\ No newline at end of file
+terraform import coolify_project.example <uuid>
+
+# Resources of another team are imported with its token from the provider `team_tokens` by appending the team ID
+terraform import coolify_project.example <uuid>/<team_id>
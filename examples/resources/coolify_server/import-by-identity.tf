@@ -0,0 +1,6 @@
+import {
+  to = coolify_server.example
+  identity = {
+    uuid = "<uuid>"
+  }
+}
@@ -0,0 +1,4 @@
+terraform import coolify_server.example <uuid>
+
+# Resources of another team are imported with its token from the provider `team_tokens` by appending the team ID
+terraform import coolify_server.example <uuid>/<team_id>
@@ -0,0 +1,6 @@
+import {
+  to = coolify_service.example
+  identity = {
+    uuid = "<service_uuid>"
+  }
+}
@@ -1 +1,7 @@
-terraform import coolify_service.example <service_uuid>
\ No newline at end of file
+terraform import coolify_service.example <service_uuid>
+
+# Alternatively, the server, project and environment can be given explicitly
+terraform import coolify_service.example <server_uuid>/<project_uuid>/<environment_name>/<service_uuid>
+
+# Resources of another team are imported with its token from the provider `team_tokens` by appending the team ID
+terraform import coolify_service.example <service_uuid>/<team_id>
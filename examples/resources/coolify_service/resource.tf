@@ -17,3 +17,7 @@ services:
 EOF
 
 }
+
+output "whoami_status" {
+  value = coolify_service.example.compose_services["whoami"].status
+}
@@ -0,0 +1,7 @@
+import {
+  to = coolify_service_env.example
+  identity = {
+    service_uuid = "<service_uuid>"
+    key          = "<key>"
+  }
+}
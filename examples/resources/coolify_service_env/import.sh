@@ -0,0 +1,4 @@
+terraform import coolify_service_env.example <service_uuid>/<key>
+
+# Resources of another team are imported with its token from the provider `team_tokens` by appending the team ID
+terraform import coolify_service_env.example <service_uuid>/<key>/<team_id>
@@ -0,0 +1,21 @@
+resource "coolify_service_env" "example" {
+  # Service UUID
+  service_uuid = "i0800ok00gcww840kk8sok0s"
+
+  key   = "SMTP_HOST"
+  value = "smtp.example.com"
+}
+
+# Keep the value out of state by using the write-only attribute (Terraform 1.11+).
+# Bump `value_wo_version` to update the value.
+ephemeral "random_password" "smtp" {
+  length = 32
+}
+
+resource "coolify_service_env" "example_write_only" {
+  service_uuid = "i0800ok00gcww840kk8sok0s"
+
+  key              = "SMTP_PASSWORD"
+  value_wo         = ephemeral.random_password.smtp.result
+  value_wo_version = 1
+}
@@ -1 +1,4 @@
-terraform import coolify_service_envs.example <service_uuid>
\ No newline at end of file
+terraform import coolify_service_envs.example <service_uuid>
+
+# Resources of another team are imported with its token from the provider `team_tokens` by appending the team ID
+terraform import coolify_service_envs.example <service_uuid>/<team_id>
@@ -2,6 +2,12 @@ resource "coolify_service_envs" "example" {
   # Service UUID
   uuid = "i0800ok00gcww840kk8sok0s"
 
+  # Delete any variable not declared below, e.g. ones added through the UI
+  exclusive = true
+
+  # Load variables from a dotenv file, `env` blocks take precedence
+  dotenv = file("${path.module}/.env.production")
+
   env {
     key   = "key1"
     value = "value1"
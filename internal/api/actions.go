@@ -0,0 +1,30 @@
+package api
+
+import (
+	"net/http"
+	"regexp"
+)
+
+// actionPaths are the endpoints that make Coolify act although they are
+// requested with GET. They are matched against the end of the request path,
+// so they apply whatever the base path of the endpoint.
+var actionPaths = []*regexp.Regexp{
+	regexp.MustCompile(`(^|/)(applications|databases|services)/[^/]+/(start|stop|restart)/?$`),
+	regexp.MustCompile(`(^|/)servers/[^/]+/validate/?$`),
+	regexp.MustCompile(`(^|/)(deploy|enable|disable)/?$`),
+}
+
+// isActionRequest reports whether a GET request starts, stops, restarts or
+// deploys a resource, validates a server, or enables or disables the API.
+// Such requests must not be retried blindly, cached or coalesced.
+func isActionRequest(method, path string) bool {
+	if method != http.MethodGet {
+		return false
+	}
+	for _, pattern := range actionPaths {
+		if pattern.MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
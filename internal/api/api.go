@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
@@ -16,6 +17,11 @@ const UserAgentPrefix = "terraform-provider-coolify"
 var (
 	TokenRegex      = regexp.MustCompile(`^\d+\|\w+$`)
 	ErrInvalidToken = errors.New("invalid token format")
+
+	// UuidRegex matches the UUIDs Coolify generates, which are CUID2 strings
+	// of lowercase letters and digits rather than RFC 4122 UUIDs.
+	UuidRegex      = regexp.MustCompile(`^[a-z0-9]{7,32}$`)
+	ErrInvalidUuid = errors.New("invalid Coolify UUID format")
 )
 
 type RetryConfig struct {
@@ -24,25 +30,69 @@ type RetryConfig struct {
 	MaxWait     int64
 }
 
-func NewAPIClient(version, server, apiToken string, retry RetryConfig) (*ClientWithResponses, error) {
+func NewAPIClient(version, server, apiToken string, retry RetryConfig, httpConfig HTTPConfig) (*ClientWithResponses, error) {
 	if err := ValidateTokenFormat(apiToken); err != nil {
 		return nil, err
 	}
 
+	transport, err := NewTransport(server, retry, httpConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewAPIClientWithTokenSource(version, server, StaticTokenSource(apiToken), transport)
+}
+
+// Transport sends the requests of API clients. Clients created with the same
+// Transport share its limit on concurrent requests and its response cache,
+// whose entries are keyed by token.
+type Transport struct {
+	roundTripper http.RoundTripper
+	headers      map[string]string
+}
+
+// NewTransport creates the transport of the clients of the endpoint at
+// server.
+func NewTransport(server string, retry RetryConfig, httpConfig HTTPConfig) (*Transport, error) {
+	attemptClient, err := newHTTPClient(httpConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	retryClient := retryablehttp.NewClient()
+	retryClient.HTTPClient = attemptClient
 	retryClient.RetryMax = int(retry.MaxAttempts)
 	retryClient.RetryWaitMin = time.Duration(retry.MinWait) * time.Second
 	retryClient.RetryWaitMax = time.Duration(retry.MaxWait) * time.Second
-	retryClient.Backoff = retryablehttp.DefaultBackoff
+	retryClient.CheckRetry = CheckRetry
+	retryClient.Backoff = Backoff
+	retryClient.RequestLogHook = requestLogHook
 	retryClient.Logger = nil
 
-	httpClient := retryClient.StandardClient()
-	httpClient.Timeout = 30 * time.Second
+	var transport http.RoundTripper = &requestInfoTransport{next: &retryablehttp.RoundTripper{Client: retryClient}}
+	if httpConfig.CacheTTL > 0 {
+		transport = newCachingTransport(transport, httpConfig.CacheTTL, server)
+	}
+
+	return &Transport{roundTripper: transport, headers: httpConfig.Headers}, nil
+}
+
+// NewAPIClientWithTokenSource creates a client that asks tokens for the token
+// of every request, and asks for a new one when Coolify rejects it.
+func NewAPIClientWithTokenSource(version, server string, tokens TokenSource, transport *Transport) (*ClientWithResponses, error) {
+	httpClient := &http.Client{Transport: &tokenRefreshTransport{next: transport.roundTripper, tokens: tokens}}
 
 	return NewClientWithResponses(server,
 		WithHTTPClient(httpClient),
 		WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
-			req.Header.Set("Authorization", "Bearer "+apiToken)
+			for name, value := range transport.headers {
+				req.Header.Set(name, value)
+			}
+			token, err := tokens.Token(ctx)
+			if err != nil {
+				return err
+			}
+			req.Header.Set("Authorization", "Bearer "+token)
 			req.Header.Set("User-Agent", fmt.Sprintf("%s/%s", UserAgentPrefix, version))
 			req.Header.Set("Accept", "application/json")
 			return nil
@@ -56,3 +106,32 @@ func ValidateTokenFormat(token string) error {
 	}
 	return nil
 }
+
+// TokenHint explains what is wrong with a token that does not match
+// TokenRegex, for the mistakes commonly made copying one. It returns an empty
+// string when the token has no recognised problem.
+func TokenHint(token string) string {
+	trimmed := strings.TrimSpace(token)
+	switch {
+	case trimmed == "":
+		return "The token is empty."
+	case trimmed != token:
+		return "The token starts or ends with whitespace, often a trailing newline from a file or secret store. Remove it."
+	case strings.HasPrefix(strings.ToLower(token), "bearer "):
+		return "The token includes the `Bearer ` prefix, which the provider adds itself. Remove it."
+	case len(token) > 1 && (token[0] == '"' || token[0] == '\'') && token[len(token)-1] == token[0]:
+		return "The token is wrapped in quotes. Remove them."
+	case !strings.Contains(token, "|"):
+		return "Coolify tokens are prefixed with their ID and a `|`, e.g. `3|abc...`. Copy the whole token shown when it was created."
+	case strings.HasSuffix(token, "|"):
+		return "The token is missing its secret after the `|`, it may have been truncated."
+	}
+	return ""
+}
+
+func ValidateUuidFormat(uuid string) error {
+	if !UuidRegex.MatchString(uuid) {
+		return ErrInvalidUuid
+	}
+	return nil
+}
@@ -763,6 +763,9 @@ type ServerSetting struct {
 
 // Service Service model
 type Service struct {
+	// Applications The applications parsed from the docker-compose.yml file.
+	Applications *[]ServiceApplication `json:"applications,omitempty"`
+
 	// ConfigHash The hash of the service configuration.
 	ConfigHash *string `json:"config_hash,omitempty"`
 
@@ -772,6 +775,9 @@ type Service struct {
 	// CreatedAt The date and time when the service was created.
 	CreatedAt *string `json:"created_at,omitempty"`
 
+	// Databases The databases parsed from the docker-compose.yml file.
+	Databases *[]ServiceDatabase `json:"databases,omitempty"`
+
 	// DeletedAt The date and time when the service was deleted.
 	DeletedAt *string `json:"deleted_at,omitempty"`
 
@@ -818,6 +824,27 @@ type Service struct {
 	Uuid *string `json:"uuid,omitempty"`
 }
 
+// ServiceApplication defines model for ServiceApplication.
+type ServiceApplication struct {
+	Description *string `json:"description"`
+	Fqdn        *string `json:"fqdn"`
+	HumanName   *string `json:"human_name"`
+	Image       *string `json:"image"`
+	Name        *string `json:"name,omitempty"`
+	Status      *string `json:"status,omitempty"`
+	Uuid        *string `json:"uuid,omitempty"`
+}
+
+// ServiceDatabase defines model for ServiceDatabase.
+type ServiceDatabase struct {
+	Description *string `json:"description"`
+	HumanName   *string `json:"human_name"`
+	Image       *string `json:"image"`
+	Name        *string `json:"name,omitempty"`
+	Status      *string `json:"status,omitempty"`
+	Uuid        *string `json:"uuid,omitempty"`
+}
+
 // Team Team model
 type Team struct {
 	// CreatedAt The date and time the team was created.
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"terraform-provider-coolify/internal/api"
@@ -49,7 +50,7 @@ func TestAPIClient(t *testing.T) {
 	}
 
 	// Test with valid token
-	client, err := api.NewAPIClient("test", mockServer.URL, MOCK_TOKEN, retryConfig)
+	client, err := api.NewAPIClient("test", mockServer.URL, MOCK_TOKEN, retryConfig, api.HTTPConfig{})
 	if err != nil {
 		t.Fatalf("Failed to create API client: %v", err)
 	}
@@ -65,7 +66,7 @@ func TestAPIClient(t *testing.T) {
 
 	// Test with invalid token
 	invalidToken := "invalid_token"
-	_, err = api.NewAPIClient("test", mockServer.URL, invalidToken, retryConfig)
+	_, err = api.NewAPIClient("test", mockServer.URL, invalidToken, retryConfig, api.HTTPConfig{})
 	if err == nil {
 		t.Fatalf("Expected error when creating API client with invalid token, got none")
 	}
@@ -93,3 +94,55 @@ func TestValidateTokenFormat(t *testing.T) {
 		})
 	}
 }
+
+func TestTokenHint(t *testing.T) {
+	tests := []struct {
+		name     string
+		token    string
+		expected string
+	}{
+		{name: "valid", token: "12345|validToken", expected: ""},
+		{name: "unrecognised", token: "12345|token with spaces", expected: ""},
+		{name: "empty", token: "", expected: "empty"},
+		{name: "trailing newline", token: "1|token\n", expected: "whitespace"},
+		{name: "bearer prefix", token: "Bearer 1|token", expected: "`Bearer `"},
+		{name: "quoted", token: `"1|token"`, expected: "quotes"},
+		{name: "missing id", token: "token", expected: "prefixed with their ID"},
+		{name: "truncated", token: "1|", expected: "truncated"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hint := api.TokenHint(tt.token)
+			if tt.expected == "" && hint != "" {
+				t.Errorf("TokenHint(%q) = %q, want no hint", tt.token, hint)
+			}
+			if !strings.Contains(hint, tt.expected) {
+				t.Errorf("TokenHint(%q) = %q, want it to mention %q", tt.token, hint, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidateUuidFormat(t *testing.T) {
+	tests := []struct {
+		uuid    string
+		wantErr bool
+	}{
+		{"rg8ks8c", false},
+		{"uoswco88w8swo40k48o8kcwk", false},
+		{"123e4567-e89b-12d3-a456-426614174000", true},
+		{"UOSWCO88W8SWO40K48O8KCWK", true},
+		{"abc", true},
+		{"", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.uuid, func(t *testing.T) {
+			err := api.ValidateUuidFormat(tt.uuid)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateUuidFormat(%q) error = %v, wantErr %v", tt.uuid, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,274 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// cachedResponse is a response with its body read, so it can be handed to
+// several callers.
+type cachedResponse struct {
+	statusCode int
+	status     string
+	proto      string
+	header     http.Header
+	body       []byte
+}
+
+func (c *cachedResponse) response(req *http.Request) *http.Response {
+	return &http.Response{
+		StatusCode:    c.statusCode,
+		Status:        c.status,
+		Proto:         c.proto,
+		Header:        c.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(c.body)),
+		ContentLength: int64(len(c.body)),
+		Request:       req,
+	}
+}
+
+type cacheEntry struct {
+	path     string
+	response *cachedResponse
+	expires  time.Time
+}
+
+// cacheablePaths are the read endpoints whose responses are cached, relative
+// to the base path of the endpoint. Other GET requests are always sent: the
+// actions matched by isActionRequest, and deployments and logs, which are
+// polled while they change.
+var cacheablePaths = regexp.MustCompile(`^/(` + strings.Join([]string{
+	`(applications|databases|services|projects|servers|resources|teams|security/keys)`,
+	`(applications|databases|services|projects|servers|teams|security/keys)/[^/]+`,
+	`(applications|services)/[^/]+/envs`,
+	`projects/[^/]+/[^/]+`,
+	`servers/[^/]+/(domains|resources)`,
+	`teams/[^/]+/members`,
+}, "|") + `)/?$`)
+
+// cachingTransport caches successful responses of read endpoints for a short
+// time, and coalesces concurrent identical reads into one request. Any other
+// request invalidates the cached responses of its path, its parents and its
+// children, so e.g. updating an application drops both the application and
+// the list of applications. Actions requested with GET, such as restarting
+// an application, invalidate the resource they act on.
+type cachingTransport struct {
+	next     http.RoundTripper
+	ttl      time.Duration
+	basePath string
+
+	mu       sync.Mutex
+	entries  map[string]cacheEntry
+	inflight map[string]*inflightRequest
+	// generation is bumped on every invalidation, so a GET that was in flight
+	// while a write happened does not cache what may be a stale response.
+	generation uint64
+}
+
+// inflightRequest is a read shared by concurrent callers. It is sent without
+// the cancellation of any one caller, and cancelled once all of them have
+// given up on it.
+type inflightRequest struct {
+	done     chan struct{}
+	response *cachedResponse
+	err      error
+	waiters  int
+	cancel   context.CancelFunc
+}
+
+// newCachingTransport returns a caching transport for the endpoint at
+// server, whose path is the base of the cached read endpoints.
+func newCachingTransport(next http.RoundTripper, ttl time.Duration, server string) *cachingTransport {
+	var basePath string
+	if parsed, err := url.Parse(server); err == nil {
+		basePath = strings.TrimSuffix(parsed.Path, "/")
+	}
+
+	return &cachingTransport{
+		next:     next,
+		ttl:      ttl,
+		basePath: basePath,
+		entries:  map[string]cacheEntry{},
+		inflight: map[string]*inflightRequest{},
+	}
+}
+
+func (t *cachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch {
+	case isActionRequest(req.Method, req.URL.Path):
+		resp, err := t.next.RoundTrip(req)
+		t.invalidate(path.Dir(strings.TrimSuffix(req.URL.Path, "/")))
+		return resp, err
+	case req.Method == http.MethodHead || req.Method == http.MethodOptions:
+		return t.next.RoundTrip(req)
+	case req.Method != http.MethodGet:
+		resp, err := t.next.RoundTrip(req)
+		t.invalidate(req.URL.Path)
+		return resp, err
+	case !t.cacheable(req.URL.Path):
+		return t.next.RoundTrip(req)
+	}
+
+	// The token is part of the key, as different tokens may see different
+	// teams.
+	key := req.URL.String() + "\x00" + req.Header.Get("Authorization")
+
+	if cached := t.lookup(key); cached != nil {
+		tflog.Debug(req.Context(), "Serving Coolify API request from cache", map[string]interface{}{
+			"url": req.URL.Redacted(),
+		})
+		return cached.response(req), nil
+	}
+
+	cached, shared, err := t.coalesce(req, key)
+	if err != nil {
+		return nil, err
+	}
+	if shared {
+		tflog.Debug(req.Context(), "Coalesced concurrent Coolify API request", map[string]interface{}{
+			"url": req.URL.Redacted(),
+		})
+	}
+
+	return cached.response(req), nil
+}
+
+func (t *cachingTransport) cacheable(requestPath string) bool {
+	relative, ok := strings.CutPrefix(requestPath, t.basePath)
+	return ok && cacheablePaths.MatchString(relative)
+}
+
+// coalesce waits for the response of the read in flight for key, sending it
+// first if there is none. It reports whether the read was already in flight.
+func (t *cachingTransport) coalesce(req *http.Request, key string) (*cachedResponse, bool, error) {
+	t.mu.Lock()
+	call, shared := t.inflight[key]
+	if !shared {
+		ctx, cancel := context.WithCancel(context.WithoutCancel(req.Context()))
+		call = &inflightRequest{done: make(chan struct{}), cancel: cancel}
+		t.inflight[key] = call
+		go t.fetch(req.WithContext(ctx), key, call, t.generation)
+	}
+	call.waiters++
+	t.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.response, shared, call.err
+	case <-req.Context().Done():
+		t.mu.Lock()
+		call.waiters--
+		if call.waiters == 0 {
+			call.cancel()
+			if t.inflight[key] == call {
+				delete(t.inflight, key)
+			}
+		}
+		t.mu.Unlock()
+		return nil, shared, req.Context().Err()
+	}
+}
+
+// fetch sends a read shared through call, and caches a successful response
+// unless an invalidation happened since generation.
+func (t *cachingTransport) fetch(req *http.Request, key string, call *inflightRequest, generation uint64) {
+	defer close(call.done)
+	defer call.cancel()
+
+	call.response, call.err = t.send(req)
+
+	t.mu.Lock()
+	if t.inflight[key] == call {
+		delete(t.inflight, key)
+	}
+	t.mu.Unlock()
+
+	if call.err == nil && call.response.statusCode == http.StatusOK {
+		t.store(key, req.URL.Path, call.response, generation)
+	}
+}
+
+func (t *cachingTransport) send(req *http.Request) (*cachedResponse, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cachedResponse{
+		statusCode: resp.StatusCode,
+		status:     resp.Status,
+		proto:      resp.Proto,
+		header:     resp.Header,
+		body:       body,
+	}, nil
+}
+
+func (t *cachingTransport) lookup(key string) *cachedResponse {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	entry, ok := t.entries[key]
+	if !ok {
+		return nil
+	}
+	if time.Now().After(entry.expires) {
+		delete(t.entries, key)
+		return nil
+	}
+	return entry.response
+}
+
+func (t *cachingTransport) store(key, requestPath string, response *cachedResponse, generation uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if generation != t.generation {
+		return
+	}
+
+	now := time.Now()
+	for existingKey, entry := range t.entries {
+		if now.After(entry.expires) {
+			delete(t.entries, existingKey)
+		}
+	}
+	t.entries[key] = cacheEntry{path: requestPath, response: response, expires: now.Add(t.ttl)}
+}
+
+func (t *cachingTransport) invalidate(requestPath string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.generation++
+	for key, entry := range t.entries {
+		if relatedPaths(requestPath, entry.path) {
+			delete(t.entries, key)
+		}
+	}
+}
+
+// relatedPaths reports whether one path is the same as, or nested under, the
+// other.
+func relatedPaths(a, b string) bool {
+	a, b = strings.TrimSuffix(a, "/"), strings.TrimSuffix(b, "/")
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	return b == a || strings.HasPrefix(b, a+"/")
+}
@@ -0,0 +1,288 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientCache(t *testing.T) {
+	var gets, lists atomic.Int64
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/projects":
+			lists.Add(1)
+			<-release
+			_, _ = w.Write([]byte(`[]`))
+		case r.Method == http.MethodGet:
+			gets.Add(1)
+			_, _ = w.Write([]byte(`{"uuid": "abc1234"}`))
+		default:
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewAPIClient("test", server.URL, "1|token", RetryConfig{MaxAttempts: 1, MinWait: 1, MaxWait: 1}, HTTPConfig{CacheTTL: time.Minute})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("concurrent requests are coalesced", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for range 5 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				resp, err := client.ListProjectsWithResponse(ctx)
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, resp.StatusCode())
+			}()
+		}
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		assert.EqualValues(t, 1, lists.Load())
+	})
+
+	t.Run("responses are cached", func(t *testing.T) {
+		_, err := client.ListProjectsWithResponse(ctx)
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, lists.Load())
+	})
+
+	t.Run("writes invalidate related paths", func(t *testing.T) {
+		_, err := client.GetProjectByUuidWithResponse(ctx, "abc1234")
+		require.NoError(t, err)
+		_, err = client.GetProjectByUuidWithResponse(ctx, "abc1234")
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, gets.Load())
+
+		_, err = client.UpdateProjectByUuidWithResponse(ctx, "abc1234", UpdateProjectByUuidJSONRequestBody{})
+		require.NoError(t, err)
+
+		_, err = client.GetProjectByUuidWithResponse(ctx, "abc1234")
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, gets.Load())
+
+		_, err = client.ListProjectsWithResponse(ctx)
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, lists.Load())
+	})
+}
+
+func TestClientCacheActions(t *testing.T) {
+	var mu sync.Mutex
+	requests := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests[r.URL.Path]++
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewAPIClient("test", server.URL+"/api/v1", "1|token", RetryConfig{MaxAttempts: 1, MinWait: 1, MaxWait: 1}, HTTPConfig{CacheTTL: time.Minute})
+	require.NoError(t, err)
+	ctx := context.Background()
+	count := func(path string) int {
+		mu.Lock()
+		defer mu.Unlock()
+		return requests[path]
+	}
+
+	t.Run("actions are always sent", func(t *testing.T) {
+		for range 2 {
+			_, err := client.RestartApplicationByUuidWithResponse(ctx, "abc1234")
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 2, count("/api/v1/applications/abc1234/restart"))
+	})
+
+	t.Run("actions invalidate the resource", func(t *testing.T) {
+		for range 2 {
+			_, err := client.GetApplicationByUuidWithResponse(ctx, "abc1234")
+			require.NoError(t, err)
+		}
+		_, err := client.ListApplicationsWithResponse(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count("/api/v1/applications/abc1234"))
+
+		_, err = client.RestartApplicationByUuidWithResponse(ctx, "abc1234")
+		require.NoError(t, err)
+
+		_, err = client.GetApplicationByUuidWithResponse(ctx, "abc1234")
+		require.NoError(t, err)
+		_, err = client.ListApplicationsWithResponse(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count("/api/v1/applications/abc1234"))
+		assert.Equal(t, 2, count("/api/v1/applications"))
+	})
+
+	t.Run("polled reads are not cached", func(t *testing.T) {
+		for range 2 {
+			_, err := client.ListDeploymentsWithResponse(ctx)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 2, count("/api/v1/deployments"))
+	})
+}
+
+func TestClientCacheCancellation(t *testing.T) {
+	release := make(chan struct{})
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		<-release
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client, err := NewAPIClient("test", server.URL, "1|token", RetryConfig{MaxAttempts: 1, MinWait: 1, MaxWait: 1}, HTTPConfig{CacheTTL: time.Minute})
+	require.NoError(t, err)
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error)
+	go func() {
+		_, err := client.ListProjectsWithResponse(cancelled)
+		firstErr <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	secondErr := make(chan error)
+	go func() {
+		_, err := client.ListProjectsWithResponse(context.Background())
+		secondErr <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	// The first caller giving up does not fail the request it shares
+	cancel()
+	assert.ErrorIs(t, <-firstErr, context.Canceled)
+	close(release)
+	assert.NoError(t, <-secondErr)
+	assert.EqualValues(t, 1, requests.Load())
+}
+
+func TestRelatedPaths(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected bool
+	}{
+		{a: "/projects", b: "/projects", expected: true},
+		{a: "/projects", b: "/projects/abc", expected: true},
+		{a: "/projects/abc/environments", b: "/projects/abc", expected: true},
+		{a: "/projects/abc", b: "/projects/abcd", expected: false},
+		{a: "/projects/abc", b: "/servers/abc", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			assert.Equal(t, tt.expected, relatedPaths(tt.a, tt.b))
+		})
+	}
+}
+
+func TestClientMaxConcurrentRequests(t *testing.T) {
+	var inFlight, peak atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			old := peak.Load()
+			if current <= old || peak.CompareAndSwap(old, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewAPIClient("test", server.URL, "1|token", RetryConfig{MaxAttempts: 1, MinWait: 1, MaxWait: 1}, HTTPConfig{MaxConcurrentRequests: 2})
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for range 6 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.CreateProjectWithResponse(context.Background(), CreateProjectJSONRequestBody{})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.EqualValues(t, 2, peak.Load())
+}
+
+func TestClientSharedTransport(t *testing.T) {
+	var inFlight, peak, lists atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			old := peak.Load()
+			if current <= old || peak.CompareAndSwap(old, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		if r.Method == http.MethodGet {
+			lists.Add(1)
+			_, _ = w.Write([]byte(`[]`))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	transport, err := NewTransport(server.URL, RetryConfig{MaxAttempts: 1, MinWait: 1, MaxWait: 1}, HTTPConfig{MaxConcurrentRequests: 2, CacheTTL: time.Minute})
+	require.NoError(t, err)
+	first, err := NewAPIClientWithTokenSource("test", server.URL, StaticTokenSource("1|first"), transport)
+	require.NoError(t, err)
+	second, err := NewAPIClientWithTokenSource("test", server.URL, StaticTokenSource("2|second"), transport)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("concurrent requests are limited across clients", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for _, client := range []*ClientWithResponses{first, second, first, second, first, second} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := client.CreateProjectWithResponse(ctx, CreateProjectJSONRequestBody{})
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		assert.EqualValues(t, 2, peak.Load())
+	})
+
+	t.Run("responses are cached per token", func(t *testing.T) {
+		for _, client := range []*ClientWithResponses{first, second, first, second} {
+			_, err := client.ListProjectsWithResponse(ctx)
+			require.NoError(t, err)
+		}
+		assert.EqualValues(t, 2, lists.Load())
+	})
+
+	t.Run("writes invalidate the responses cached by other clients", func(t *testing.T) {
+		_, err := first.CreateProjectWithResponse(ctx, CreateProjectJSONRequestBody{})
+		require.NoError(t, err)
+
+		_, err = second.ListProjectsWithResponse(ctx)
+		require.NoError(t, err)
+		assert.EqualValues(t, 3, lists.Load())
+	})
+}
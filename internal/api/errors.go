@@ -0,0 +1,59 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// ValidationError is the body Coolify returns with 422 Unprocessable Entity
+// when Laravel rejects a request, e.g.
+//
+//	{"message": "Validation failed.", "errors": {"name": ["The name field is required."]}}
+type ValidationError struct {
+	Message string              `json:"message"`
+	Errors  map[string][]string `json:"errors"`
+}
+
+// ParseValidationError decodes a validation error from a response. It returns
+// nil when the response is not a 422 or the body is not shaped like one.
+func ParseValidationError(statusCode int, body []byte) *ValidationError {
+	if statusCode != http.StatusUnprocessableEntity {
+		return nil
+	}
+
+	var validationErr ValidationError
+	if err := json.Unmarshal(body, &validationErr); err != nil {
+		return nil
+	}
+	if validationErr.Message == "" && len(validationErr.Errors) == 0 {
+		return nil
+	}
+
+	return &validationErr
+}
+
+// Fields returns the names of the invalid fields, sorted so diagnostics are
+// reported in a stable order.
+func (e *ValidationError) Fields() []string {
+	fields := make([]string, 0, len(e.Errors))
+	for field := range e.Errors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Errors) == 0 {
+		return e.Message
+	}
+
+	var messages []string
+	for _, field := range e.Fields() {
+		messages = append(messages, fmt.Sprintf("%s: %s", field, strings.Join(e.Errors[field], " ")))
+	}
+	return strings.Join(messages, "\n")
+}
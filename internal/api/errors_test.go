@@ -0,0 +1,61 @@
+package api_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"terraform-provider-coolify/internal/api"
+)
+
+func TestParseValidationError(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		expected   *api.ValidationError
+	}{
+		{
+			name:       "field errors",
+			statusCode: http.StatusUnprocessableEntity,
+			body:       `{"message": "Validation failed.", "errors": {"name": ["The name field is required."], "ip": ["The ip field must be a valid IP address.", "The ip field is invalid."]}}`,
+			expected: &api.ValidationError{
+				Message: "Validation failed.",
+				Errors: map[string][]string{
+					"name": {"The name field is required."},
+					"ip":   {"The ip field must be a valid IP address.", "The ip field is invalid."},
+				},
+			},
+		},
+		{
+			name:       "message only",
+			statusCode: http.StatusUnprocessableEntity,
+			body:       `{"message": "Server is not reachable."}`,
+			expected:   &api.ValidationError{Message: "Server is not reachable."},
+		},
+		{name: "other status", statusCode: http.StatusBadRequest, body: `{"message": "Bad request."}`},
+		{name: "not json", statusCode: http.StatusUnprocessableEntity, body: `Unprocessable`},
+		{name: "empty object", statusCode: http.StatusUnprocessableEntity, body: `{}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, api.ParseValidationError(tt.statusCode, []byte(tt.body)))
+		})
+	}
+}
+
+func TestValidationErrorError(t *testing.T) {
+	err := &api.ValidationError{
+		Message: "Validation failed.",
+		Errors: map[string][]string{
+			"name": {"The name field is required."},
+			"ip":   {"The ip field must be a valid IP address."},
+		},
+	}
+	assert.Equal(t, []string{"ip", "name"}, err.Fields())
+	assert.EqualError(t, err, "ip: The ip field must be a valid IP address.\nname: The name field is required.")
+
+	assert.EqualError(t, &api.ValidationError{Message: "Server is not reachable."}, "Server is not reachable.")
+}
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const DefaultRequestTimeout = 30 * time.Second
+
+// HTTPConfig configures how the client connects to Coolify. The zero value
+// uses the system CA pool, the proxy environment variables and the default
+// request timeout.
+type HTTPConfig struct {
+	// Timeout of each request attempt, retries are timed separately.
+	Timeout time.Duration
+
+	// CACertPEM is added to the system CA pool, for Coolify instances using
+	// an internal CA.
+	CACertPEM          []byte
+	InsecureSkipVerify bool
+
+	// ClientCertPEM and ClientKeyPEM are presented for mutual TLS.
+	ClientCertPEM []byte
+	ClientKeyPEM  []byte
+
+	// ProxyURL overrides the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment
+	// variables.
+	ProxyURL *url.URL
+
+	// Headers are sent with every request.
+	Headers map[string]string
+
+	// MaxConcurrentRequests limits how many requests are sent to Coolify at
+	// once, zero means no limit.
+	MaxConcurrentRequests int64
+
+	// CacheTTL is how long successful GET responses are reused, zero disables
+	// caching.
+	CacheTTL time.Duration
+}
+
+var (
+	ErrInvalidCACert     = errors.New("no valid certificates found in CA certificate PEM")
+	ErrIncompleteKeyPair = errors.New("client certificate and key must be set together")
+)
+
+// newHTTPClient returns the client used for each request attempt.
+func newHTTPClient(config HTTPConfig) (*http.Client, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: config.InsecureSkipVerify,
+	}
+
+	if len(config.CACertPEM) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(config.CACertPEM) {
+			return nil, ErrInvalidCACert
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if len(config.ClientCertPEM) > 0 || len(config.ClientKeyPEM) > 0 {
+		if len(config.ClientCertPEM) == 0 || len(config.ClientKeyPEM) == 0 {
+			return nil, ErrIncompleteKeyPair
+		}
+		cert, err := tls.X509KeyPair(config.ClientCertPEM, config.ClientKeyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate or key: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	if config.ProxyURL != nil {
+		transport.Proxy = http.ProxyURL(config.ProxyURL)
+	}
+
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = DefaultRequestTimeout
+	}
+
+	var roundTripper http.RoundTripper = transport
+	if config.MaxConcurrentRequests > 0 {
+		roundTripper = &limitedTransport{next: transport, slots: make(chan struct{}, config.MaxConcurrentRequests)}
+	}
+
+	return &http.Client{Transport: roundTripper, Timeout: timeout}, nil
+}
+
+// limitedTransport limits the number of requests in flight. A slot is held
+// until the response body is closed, and is not held while waiting to retry.
+type limitedTransport struct {
+	next  http.RoundTripper
+	slots chan struct{}
+}
+
+func (t *limitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	select {
+	case t.slots <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		<-t.slots
+		return nil, err
+	}
+
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: func() { <-t.slots }}
+	return resp, nil
+}
+
+// releasingBody releases a request slot once the body is closed.
+type releasingBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
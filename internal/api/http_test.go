@@ -0,0 +1,85 @@
+package api
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHTTPClientErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   HTTPConfig
+		expected error
+	}{
+		{name: "invalid ca", config: HTTPConfig{CACertPEM: []byte("not a certificate")}, expected: ErrInvalidCACert},
+		{name: "cert without key", config: HTTPConfig{ClientCertPEM: []byte("cert")}, expected: ErrIncompleteKeyPair},
+		{name: "key without cert", config: HTTPConfig{ClientKeyPEM: []byte("key")}, expected: ErrIncompleteKeyPair},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newHTTPClient(tt.config)
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+
+	_, err := newHTTPClient(HTTPConfig{ClientCertPEM: []byte("cert"), ClientKeyPEM: []byte("key")})
+	assert.ErrorContains(t, err, "invalid client certificate or key")
+}
+
+func TestClientHTTPConfig(t *testing.T) {
+	var received http.Header
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+		if strings.HasPrefix(r.URL.Path, "/slow") {
+			time.Sleep(200 * time.Millisecond)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("4.0.0"))
+	}))
+	defer server.Close()
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	retry := RetryConfig{MaxAttempts: 1, MinWait: 1, MaxWait: 1}
+
+	t.Run("untrusted certificate", func(t *testing.T) {
+		client, err := NewAPIClient("test", server.URL, "1|token", retry, HTTPConfig{})
+		require.NoError(t, err)
+
+		_, err = client.VersionWithResponse(context.Background())
+		assert.Error(t, err)
+	})
+
+	t.Run("custom ca and headers", func(t *testing.T) {
+		client, err := NewAPIClient("test", server.URL, "1|token", retry, HTTPConfig{
+			CACertPEM: caPEM,
+			Headers:   map[string]string{"X-Team": "platform"},
+		})
+		require.NoError(t, err)
+
+		resp, err := client.VersionWithResponse(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode())
+		assert.Equal(t, "platform", received.Get("X-Team"))
+		assert.Equal(t, "Bearer 1|token", received.Get("Authorization"))
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		client, err := NewAPIClient("test", server.URL+"/slow", "1|token", retry, HTTPConfig{
+			CACertPEM: caPEM,
+			Timeout:   50 * time.Millisecond,
+		})
+		require.NoError(t, err)
+
+		_, err = client.VersionWithResponse(context.Background())
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,184 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptrace"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// RequestIdHeader is sent with every request, so retries of the same request
+// can be correlated in the provider and Coolify logs.
+const RequestIdHeader = "X-Request-Id"
+
+type requestInfoKey struct{}
+
+// requestInfo tracks a request across its retry attempts.
+type requestInfo struct {
+	id      string
+	method  string
+	path    string
+	attempt atomic.Int64
+	// sent is set once any part of the current attempt has been written to
+	// the connection.
+	sent atomic.Bool
+}
+
+func requestInfoFromContext(ctx context.Context) *requestInfo {
+	info, _ := ctx.Value(requestInfoKey{}).(*requestInfo)
+	return info
+}
+
+// requestInfoTransport assigns each request an ID and tracks whether an
+// attempt has written anything, before handing it to the retrying transport.
+type requestInfoTransport struct {
+	next http.RoundTripper
+}
+
+func (t *requestInfoTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	info := &requestInfo{id: newRequestId(), method: req.Method, path: req.URL.Path}
+	ctx := context.WithValue(req.Context(), requestInfoKey{}, info)
+	ctx = httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		WroteHeaders: func() { info.sent.Store(true) },
+	})
+
+	req = req.Clone(ctx)
+	req.Header.Set(RequestIdHeader, info.id)
+
+	return t.next.RoundTrip(req)
+}
+
+func newRequestId() string {
+	id := make([]byte, 8)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// isIdempotent reports whether a request can be safely sent more than once.
+// GET requests that act on a resource, such as restarting it, are not.
+func isIdempotent(method, path string) bool {
+	if isActionRequest(method, path) {
+		return false
+	}
+
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// requestLogHook is called before every attempt of a request.
+func requestLogHook(_ retryablehttp.Logger, req *http.Request, attempt int) {
+	info := requestInfoFromContext(req.Context())
+	if info == nil {
+		return
+	}
+
+	info.attempt.Store(int64(attempt))
+	info.sent.Store(false)
+}
+
+// CheckRetry retries rate limited requests, and otherwise follows the default
+// policy of retrying connection errors and 5xx responses. Requests that are
+// not idempotent, such as creating a database or restarting an application,
+// are only retried when the attempt failed before anything was sent, as
+// Coolify may otherwise have acted on them.
+func CheckRetry(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+
+	info := requestInfoFromContext(ctx)
+	idempotent := info == nil || isIdempotent(info.method, info.path)
+
+	var retry bool
+	var checkErr error
+	switch {
+	case err != nil:
+		if !idempotent && info.sent.Load() {
+			return false, err
+		}
+		retry, checkErr = retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+	case resp.StatusCode == http.StatusTooManyRequests:
+		retry = true
+	case !idempotent:
+		retry = false
+	default:
+		retry, checkErr = retryablehttp.DefaultRetryPolicy(ctx, resp, nil)
+	}
+
+	if retry && info != nil {
+		fields := map[string]interface{}{
+			"request_id": info.id,
+			"method":     info.method,
+			"attempt":    info.attempt.Load() + 1,
+		}
+		if resp != nil {
+			fields["status"] = resp.Status
+			fields["url"] = resp.Request.URL.Redacted()
+		}
+		if err != nil {
+			fields["error"] = err.Error()
+		}
+		tflog.Warn(ctx, "Retrying Coolify API request", fields)
+	}
+
+	return retry, checkErr
+}
+
+// Backoff waits as long as Coolify asks when rate limited, using the
+// Retry-After header or, failing that, the X-RateLimit-* headers, up to max.
+// Other retries use exponential backoff.
+func Backoff(min, max time.Duration, attemptNum int, resp *http.Response) time.Duration {
+	if resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
+		if wait, ok := rateLimitWait(resp.Header, time.Now()); ok {
+			if wait > max {
+				// A far-future reset, e.g. from a misconfigured proxy, must not
+				// stall the apply
+				ctx := context.Background()
+				if resp.Request != nil {
+					ctx = resp.Request.Context()
+				}
+				tflog.Warn(ctx, "Rate limit wait exceeds the maximum retry wait", map[string]interface{}{
+					"requested_wait": wait.String(),
+					"max_wait":       max.String(),
+				})
+				return max
+			}
+			return wait
+		}
+	}
+
+	return retryablehttp.DefaultBackoff(min, max, attemptNum, resp)
+}
+
+// rateLimitWait returns how long the rate limit headers of a response ask
+// clients to wait before retrying.
+func rateLimitWait(header http.Header, now time.Time) (time.Duration, bool) {
+	if retryAfter := header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.ParseInt(retryAfter, 10, 64); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if date, err := http.ParseTime(retryAfter); err == nil {
+			return max(date.Sub(now), 0), true
+		}
+	}
+
+	// The reset time is a Unix timestamp, and only applies once the limit
+	// has been used up
+	if header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			return max(time.Unix(reset, 0).Sub(now), 0), true
+		}
+	}
+
+	return 0, false
+}
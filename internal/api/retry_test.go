@@ -0,0 +1,204 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientRetries(t *testing.T) {
+	tests := []struct {
+		name             string
+		status           int
+		post             bool
+		action           bool
+		expectedAttempts int64
+	}{
+		{name: "get unavailable", status: http.StatusServiceUnavailable, expectedAttempts: 3},
+		{name: "get rate limited", status: http.StatusTooManyRequests, expectedAttempts: 3},
+		{name: "post unavailable", status: http.StatusServiceUnavailable, post: true, expectedAttempts: 1},
+		{name: "post rate limited", status: http.StatusTooManyRequests, post: true, expectedAttempts: 3},
+		{name: "get not found", status: http.StatusNotFound, expectedAttempts: 1},
+		{name: "action unavailable", status: http.StatusServiceUnavailable, action: true, expectedAttempts: 1},
+		{name: "action rate limited", status: http.StatusTooManyRequests, action: true, expectedAttempts: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int64
+			requestIds := map[string]bool{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts.Add(1)
+				requestIds[r.Header.Get(RequestIdHeader)] = true
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			client, err := NewAPIClient("test", server.URL, "1|token", RetryConfig{MaxAttempts: 2, MinWait: 1, MaxWait: 1}, HTTPConfig{})
+			require.NoError(t, err)
+
+			switch {
+			case tt.post:
+				_, _ = client.CreateProjectWithResponse(context.Background(), CreateProjectJSONRequestBody{})
+			case tt.action:
+				_, _ = client.RestartApplicationByUuidWithResponse(context.Background(), "abc1234")
+			default:
+				_, _ = client.ListProjectsWithResponse(context.Background())
+			}
+
+			assert.Equal(t, tt.expectedAttempts, attempts.Load())
+			assert.Len(t, requestIds, 1, "attempts should share a request ID")
+		})
+	}
+}
+
+func TestCheckRetryConnectionErrors(t *testing.T) {
+	connErr := errors.New("connection reset by peer")
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		sent     bool
+		expected bool
+	}{
+		{name: "get before sending", method: http.MethodGet, expected: true},
+		{name: "get after sending", method: http.MethodGet, sent: true, expected: true},
+		{name: "action before sending", method: http.MethodGet, path: "/api/v1/services/abc/stop", expected: true},
+		{name: "action after sending", method: http.MethodGet, path: "/api/v1/services/abc/stop", sent: true, expected: false},
+		{name: "post before sending", method: http.MethodPost, expected: true},
+		{name: "post after sending", method: http.MethodPost, sent: true, expected: false},
+		{name: "patch after sending", method: http.MethodPatch, sent: true, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := &requestInfo{id: "test", method: tt.method, path: tt.path}
+			info.sent.Store(tt.sent)
+			ctx := context.WithValue(context.Background(), requestInfoKey{}, info)
+
+			retry, err := CheckRetry(ctx, nil, connErr)
+			assert.Equal(t, tt.expected, retry)
+			assert.Equal(t, !tt.expected, err != nil)
+		})
+	}
+}
+
+func TestIsActionRequest(t *testing.T) {
+	tests := []struct {
+		method   string
+		path     string
+		expected bool
+	}{
+		{method: http.MethodGet, path: "/api/v1/applications/abc/start", expected: true},
+		{method: http.MethodGet, path: "/api/v1/databases/abc/stop", expected: true},
+		{method: http.MethodGet, path: "/services/abc/restart", expected: true},
+		{method: http.MethodGet, path: "/api/v1/deploy", expected: true},
+		{method: http.MethodGet, path: "/api/v1/servers/abc/validate", expected: true},
+		{method: http.MethodGet, path: "/api/v1/enable", expected: true},
+		{method: http.MethodGet, path: "/api/v1/applications/abc", expected: false},
+		{method: http.MethodGet, path: "/api/v1/applications/abc/envs", expected: false},
+		{method: http.MethodGet, path: "/api/v1/deployments", expected: false},
+		{method: http.MethodGet, path: "/api/v1/projects/abc/restart", expected: false},
+		{method: http.MethodPost, path: "/api/v1/applications/abc/start", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isActionRequest(tt.method, tt.path))
+		})
+	}
+}
+
+func TestRateLimitWait(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		header     http.Header
+		expected   time.Duration
+		expectedOk bool
+	}{
+		{
+			name:       "retry after seconds",
+			header:     http.Header{"Retry-After": {"30"}},
+			expected:   30 * time.Second,
+			expectedOk: true,
+		},
+		{
+			name:       "retry after date",
+			header:     http.Header{"Retry-After": {now.Add(time.Minute).Format(http.TimeFormat)}},
+			expected:   time.Minute,
+			expectedOk: true,
+		},
+		{
+			name:       "retry after date in the past",
+			header:     http.Header{"Retry-After": {now.Add(-time.Minute).Format(http.TimeFormat)}},
+			expected:   0,
+			expectedOk: true,
+		},
+		{
+			name: "rate limit reset",
+			header: http.Header{
+				"X-Ratelimit-Remaining": {"0"},
+				"X-Ratelimit-Reset":     {strconv.FormatInt(now.Add(45*time.Second).Unix(), 10)},
+			},
+			expected:   45 * time.Second,
+			expectedOk: true,
+		},
+		{
+			name: "rate limit not used up",
+			header: http.Header{
+				"X-Ratelimit-Remaining": {"10"},
+				"X-Ratelimit-Reset":     {strconv.FormatInt(now.Add(45*time.Second).Unix(), 10)},
+			},
+		},
+		{
+			name:   "no headers",
+			header: http.Header{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wait, ok := rateLimitWait(tt.header, now)
+			assert.Equal(t, tt.expectedOk, ok)
+			assert.Equal(t, tt.expected, wait)
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	rateLimited := func(retryAfter string) *http.Response {
+		return &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": {retryAfter}}}
+	}
+
+	tests := []struct {
+		name     string
+		resp     *http.Response
+		expected time.Duration
+	}{
+		{"retry after within max", rateLimited("5"), 5 * time.Second},
+		{"retry after clamped to max", rateLimited("3600"), 30 * time.Second},
+		{"far future reset clamped to max", &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{
+			"X-Ratelimit-Remaining": {"0"},
+			"X-Ratelimit-Reset":     {strconv.FormatInt(time.Now().Add(24*time.Hour).Unix(), 10)},
+		}}, 30 * time.Second},
+		{"no rate limit headers", &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}, 4 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Backoff(time.Second, 30*time.Second, 2, tt.resp))
+		})
+	}
+}
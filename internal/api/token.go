@@ -0,0 +1,168 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// TokenSource provides the token sent with each request.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+	// Invalidate discards the token after Coolify rejected it, so the next
+	// call to Token fetches a new one.
+	Invalidate(token string)
+}
+
+type staticTokenSource string
+
+// StaticTokenSource always returns the same token.
+func StaticTokenSource(token string) TokenSource {
+	return staticTokenSource(token)
+}
+
+func (s staticTokenSource) Token(context.Context) (string, error) { return string(s), nil }
+
+func (s staticTokenSource) Invalidate(string) {}
+
+const (
+	// DefaultTokenCommandTimeout bounds how long a credential helper may
+	// run, e.g. while waiting for a Vault agent.
+	DefaultTokenCommandTimeout = time.Minute
+
+	// tokenExpiryMargin refreshes tokens shortly before they expire, so they
+	// do not expire between being fetched and being used.
+	tokenExpiryMargin = 30 * time.Second
+)
+
+var ErrEmptyTokenCommand = errors.New("token command returned an empty token")
+
+// CommandTokenSource runs a credential helper to obtain the token, and reuses
+// it until it expires or Coolify rejects it. The command prints either the
+// token, or a JSON object such as
+//
+//	{"token": "1|...", "expires_at": "2025-01-01T00:00:00Z"}
+type CommandTokenSource struct {
+	command []string
+	timeout time.Duration
+	now     func() time.Time
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+func NewCommandTokenSource(command []string) *CommandTokenSource {
+	return &CommandTokenSource{
+		command: command,
+		timeout: DefaultTokenCommandTimeout,
+		now:     time.Now,
+	}
+}
+
+type tokenCommandOutput struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func (s *CommandTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && (s.expiresAt.IsZero() || s.now().Add(tokenExpiryMargin).Before(s.expiresAt)) {
+		return s.token, nil
+	}
+
+	tflog.Debug(ctx, "Running token command", map[string]interface{}{"command": s.command[0]})
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, s.command[0], s.command[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", fmt.Errorf("token command failed: %w: %s", err, message)
+		}
+		return "", fmt.Errorf("token command failed: %w", err)
+	}
+
+	output := tokenCommandOutput{Token: strings.TrimSpace(stdout.String())}
+	if strings.HasPrefix(output.Token, "{") {
+		output = tokenCommandOutput{}
+		if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+			return "", fmt.Errorf("token command returned invalid JSON: %w", err)
+		}
+	}
+	if output.Token == "" {
+		return "", ErrEmptyTokenCommand
+	}
+
+	s.token, s.expiresAt = output.Token, output.ExpiresAt
+	return s.token, nil
+}
+
+func (s *CommandTokenSource) Invalidate(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token == token {
+		s.token, s.expiresAt = "", time.Time{}
+	}
+}
+
+// tokenRefreshTransport sends a request once more with a new token when
+// Coolify rejects the token it was sent with. As the request was rejected
+// before being acted on, this is safe for any method.
+type tokenRefreshTransport struct {
+	next   http.RoundTripper
+	tokens TokenSource
+}
+
+func (t *tokenRefreshTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	if req.Body != nil && req.GetBody == nil {
+		return resp, nil
+	}
+
+	sent := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+	t.tokens.Invalidate(sent)
+	token, tokenErr := t.tokens.Token(req.Context())
+	if tokenErr != nil {
+		tflog.Warn(req.Context(), "Failed to refresh rejected Coolify token", map[string]interface{}{"error": tokenErr.Error()})
+		return resp, nil
+	}
+	if token == sent {
+		return resp, nil
+	}
+
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, bodyErr := req.GetBody()
+		if bodyErr != nil {
+			return resp, nil
+		}
+		retry.Body = body
+	}
+	retry.Header.Set("Authorization", "Bearer "+token)
+
+	tflog.Debug(req.Context(), "Retrying Coolify API request with a refreshed token", map[string]interface{}{
+		"url": req.URL.Redacted(),
+	})
+	_ = resp.Body.Close()
+	return t.next.RoundTrip(retry)
+}
@@ -0,0 +1,118 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingCommand returns a command printing the output for the number of
+// times it has been run, starting at 1.
+func countingCommand(t *testing.T, outputs ...string) []string {
+	dir := t.TempDir()
+	script := "n=$(cat " + filepath.Join(dir, "count") + " 2>/dev/null || echo 0); n=$((n+1)); echo $n > " + filepath.Join(dir, "count") + "; case $n in\n"
+	for i, output := range outputs {
+		script += "  " + string(rune('1'+i)) + ") echo '" + output + "' ;;\n"
+	}
+	script += "  *) echo 'out of tokens' >&2; exit 1 ;;\nesac\n"
+
+	path := filepath.Join(dir, "token.sh")
+	require.NoError(t, os.WriteFile(path, []byte(script), 0o700))
+	return []string{"sh", path}
+}
+
+func TestCommandTokenSource(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		command       []string
+		expected      []string
+		expectedError string
+	}{
+		{
+			name:     "plain token is cached",
+			command:  countingCommand(t, "1|first", "1|second"),
+			expected: []string{"1|first", "1|first"},
+		},
+		{
+			name:     "json token is cached until it expires",
+			command:  countingCommand(t, `{"token": "1|first", "expires_at": "2025-01-01T00:10:00Z"}`, `{"token": "1|second"}`),
+			expected: []string{"1|first", "1|first", "1|second"},
+		},
+		{
+			name:          "failing command",
+			command:       []string{"sh", "-c", "echo 'vault is sealed' >&2; exit 2"},
+			expectedError: "token command failed: exit status 2: vault is sealed",
+		},
+		{
+			name:          "empty output",
+			command:       []string{"sh", "-c", "echo"},
+			expectedError: ErrEmptyTokenCommand.Error(),
+		},
+		{
+			name:          "invalid json",
+			command:       []string{"sh", "-c", "echo '{token'"},
+			expectedError: "token command returned invalid JSON",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := NewCommandTokenSource(tt.command)
+			clock := now
+			source.now = func() time.Time { return clock }
+
+			if tt.expectedError != "" {
+				_, err := source.Token(context.Background())
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+
+			for _, expected := range tt.expected {
+				token, err := source.Token(context.Background())
+				require.NoError(t, err)
+				assert.Equal(t, expected, token)
+				clock = clock.Add(5 * time.Minute)
+			}
+		})
+	}
+}
+
+func TestClientRefreshesRejectedToken(t *testing.T) {
+	var tokens []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokens = append(tokens, r.Header.Get("Authorization"))
+		if r.Header.Get("Authorization") != "Bearer 1|second" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"uuid": "abc1234"}`))
+	}))
+	defer server.Close()
+
+	source := NewCommandTokenSource(countingCommand(t, "1|first", "1|second"))
+	transport, err := NewTransport(server.URL, RetryConfig{MaxAttempts: 1, MinWait: 1, MaxWait: 1}, HTTPConfig{})
+	require.NoError(t, err)
+	client, err := NewAPIClientWithTokenSource("test", server.URL, source, transport)
+	require.NoError(t, err)
+
+	name := "example"
+	resp, err := client.CreateProjectWithResponse(context.Background(), CreateProjectJSONRequestBody{Name: &name})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode())
+	assert.Equal(t, []string{"Bearer 1|first", "Bearer 1|second"}, tokens)
+
+	// The refreshed token is reused by later requests
+	_, err = client.CreateProjectWithResponse(context.Background(), CreateProjectJSONRequestBody{Name: &name})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Bearer 1|first", "Bearer 1|second", "Bearer 1|second"}, tokens)
+}
@@ -0,0 +1,100 @@
+package capability
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+// Capability is a feature of the Coolify API that older versions supported by
+// the provider lack.
+type Capability string
+
+const (
+	// EnvironmentUuid is placing resources by `environment_uuid` rather than
+	// `environment_name`. It is not registered, as the release introducing it
+	// has not been pinned down.
+	EnvironmentUuid Capability = "environment_uuid"
+)
+
+type requirement struct {
+	minVersion string
+	// source is where minVersion comes from, such as the Coolify release
+	// notes introducing the capability.
+	source string
+	// alternative explains what to use on older versions instead, if
+	// anything.
+	alternative string
+}
+
+// registry is the first Coolify version supporting each capability. Anything
+// not listed is assumed to be supported by MIN_COOLIFY_VERSION, so only
+// capabilities whose introducing release is known are listed.
+//
+// The PostgreSQL and MySQL engines managed by the provider are not listed, as
+// their endpoints predate MIN_COOLIFY_VERSION. Resources for other database
+// engines must register a capability, with the release introducing them as
+// its source, when they are added.
+var registry = map[Capability]requirement{}
+
+// Describe appends the version a capability needs to an attribute
+// description, if it is registered.
+func Describe(description string, capability Capability) string {
+	req, ok := registry[capability]
+	if !ok {
+		return description
+	}
+	return fmt.Sprintf("%s Requires Coolify %s or later.", description, req.minVersion)
+}
+
+// Supports reports whether a Coolify version supports the capability. An
+// unknown or unparsable version is assumed to support everything, so the API
+// has the final say.
+func Supports(version string, capability Capability) bool {
+	req, ok := registry[capability]
+	if !ok {
+		return true
+	}
+	if major, _, _, _ := ParseVersion(version); major == 0 {
+		return true
+	}
+	return IsVersionCompatible(version, req.minVersion)
+}
+
+// Check returns an error describing why the capability is unavailable, or
+// nil when the version supports it.
+func Check(version string, capability Capability) error {
+	if Supports(version, capability) {
+		return nil
+	}
+
+	req := registry[capability]
+	message := fmt.Sprintf("%s requires Coolify %s or later, but the server runs %s.", capability, req.minVersion, version)
+	if req.alternative != "" {
+		message += " " + req.alternative
+	}
+	return fmt.Errorf("%s", message)
+}
+
+// RequireAttribute reports an error against an attribute that is set in the
+// configuration when the Coolify version does not support the capability it
+// needs, so it fails at plan time rather than with an opaque API error.
+func RequireAttribute(ctx context.Context, version string, config tfsdk.Config, attribute path.Path, capability Capability, diags *diag.Diagnostics) {
+	if config.Raw.IsNull() {
+		return
+	}
+
+	var value attr.Value
+	diags.Append(config.GetAttribute(ctx, attribute, &value)...)
+	if value == nil || value.IsNull() {
+		return
+	}
+
+	if err := Check(version, capability); err != nil {
+		diags.AddAttributeError(attribute, "Unsupported Coolify version", fmt.Sprintf("`%s` is not available: %s", attribute, err))
+	}
+}
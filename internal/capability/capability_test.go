@@ -0,0 +1,127 @@
+package capability
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"terraform-provider-coolify/internal/consts"
+)
+
+const testCapability Capability = "test_capability"
+
+// registerTestCapability registers a capability for the duration of a test.
+func registerTestCapability(t *testing.T) requirement {
+	req := requirement{
+		minVersion:  "4.0.0-beta.400",
+		source:      "Test",
+		alternative: "Use `environment_name` instead.",
+	}
+	registry[testCapability] = req
+	t.Cleanup(func() { delete(registry, testCapability) })
+	return req
+}
+
+func TestSupports(t *testing.T) {
+	minVersion := registerTestCapability(t).minVersion
+
+	tests := []struct {
+		name       string
+		version    string
+		capability Capability
+		expected   bool
+	}{
+		{name: "minimum version", version: minVersion, capability: testCapability, expected: true},
+		{name: "newer version", version: "4.0.0", capability: testCapability, expected: true},
+		{name: "older version", version: "4.0.0-beta.381", capability: testCapability, expected: false},
+		{name: "unknown version", version: "", capability: testCapability, expected: true},
+		{name: "unparsable version", version: "nightly", capability: testCapability, expected: true},
+		{name: "unregistered capability", version: "4.0.0-beta.381", capability: "unregistered", expected: true},
+		{name: "environment uuid", version: consts.MIN_COOLIFY_VERSION, capability: EnvironmentUuid, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Supports(tt.version, tt.capability))
+			assert.Equal(t, !tt.expected, Check(tt.version, tt.capability) != nil)
+		})
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	for capability, req := range registry {
+		t.Run(string(capability), func(t *testing.T) {
+			assert.NotEmpty(t, req.source, "minimum versions must cite where they come from")
+			assert.True(t, IsVersionCompatible(req.minVersion, consts.MIN_COOLIFY_VERSION), "minimum version predates MIN_COOLIFY_VERSION")
+		})
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	minVersion := registerTestCapability(t).minVersion
+
+	assert.Equal(t, "Description. Requires Coolify "+minVersion+" or later.", Describe("Description.", testCapability))
+	assert.Equal(t, "Description.", Describe("Description.", "unregistered"))
+}
+
+func TestCheck(t *testing.T) {
+	minVersion := registerTestCapability(t).minVersion
+
+	err := Check("4.0.0-beta.381", testCapability)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "requires Coolify "+minVersion+" or later")
+	assert.Contains(t, err.Error(), "runs 4.0.0-beta.381")
+	assert.Contains(t, err.Error(), "`environment_name`")
+}
+
+func TestRequireAttribute(t *testing.T) {
+	registerTestCapability(t)
+
+	configSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"environment_uuid": schema.StringAttribute{Optional: true},
+		},
+	}
+	objectType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"environment_uuid": tftypes.String}}
+
+	tests := []struct {
+		name          string
+		value         interface{}
+		version       string
+		expectedError bool
+	}{
+		{name: "set on old version", value: "abc1234", version: "4.0.0-beta.381", expectedError: true},
+		{name: "set on new version", value: "abc1234", version: "4.0.0", expectedError: false},
+		{name: "unset on old version", value: nil, version: "4.0.0-beta.381", expectedError: false},
+		{name: "unknown on old version", value: tftypes.UnknownValue, version: "4.0.0-beta.381", expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tfsdk.Config{
+				Schema: configSchema,
+				Raw: tftypes.NewValue(objectType, map[string]tftypes.Value{
+					"environment_uuid": tftypes.NewValue(tftypes.String, tt.value),
+				}),
+			}
+
+			var diags diag.Diagnostics
+			RequireAttribute(context.Background(), tt.version, config, path.Root("environment_uuid"), testCapability, &diags)
+			assert.Equal(t, tt.expectedError, diags.HasError(), diags)
+		})
+	}
+
+	t.Run("destroy", func(t *testing.T) {
+		var diags diag.Diagnostics
+		config := tfsdk.Config{Schema: configSchema, Raw: tftypes.NewValue(objectType, nil)}
+		RequireAttribute(context.Background(), "4.0.0-beta.381", config, path.Root("environment_uuid"), testCapability, &diags)
+		assert.False(t, diags.HasError())
+	})
+}
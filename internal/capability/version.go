@@ -1,18 +1,18 @@
-package provider
+package capability
 
 import (
 	"strconv"
 	"strings"
 )
 
-// parseVersion parses a version string and returns the major, minor, patch, and beta versions as integers.
+// ParseVersion parses a version string and returns the major, minor, patch, and beta versions as integers.
 // The version string is expected to be in the format "major.minor.patch-beta.betaVersion".
 // For example, given the version string "4.0.0-beta.360", it will return:
 // major = 4, minor = 0, patch = 0, beta = 360.
-func parseVersion(version string) (major, minor, patch, beta int) {
+func ParseVersion(version string) (major, minor, patch, beta int) {
 	// Remove 'v' prefix if present
 	version = strings.TrimPrefix(version, "v")
-	
+
 	// Example version string: "4.0.0-beta.360"
 	parts := strings.Split(version, "-")
 	versionParts := strings.Split(parts[0], ".")
@@ -37,11 +37,11 @@ func parseVersion(version string) (major, minor, patch, beta int) {
 	return
 }
 
-// isVersionCompatible checks if the current version is compatible with the minimum required version.
+// IsVersionCompatible checks if the current version is compatible with the minimum required version.
 // It compares the major, minor, patch, and beta versions in sequence to determine compatibility.
-func isVersionCompatible(currentVersion, minVersion string) bool {
-	currentMajor, currentMinor, currentPatch, currentBeta := parseVersion(currentVersion)
-	minMajor, minMinor, minPatch, minBeta := parseVersion(minVersion)
+func IsVersionCompatible(currentVersion, minVersion string) bool {
+	currentMajor, currentMinor, currentPatch, currentBeta := ParseVersion(currentVersion)
+	minMajor, minMinor, minPatch, minBeta := ParseVersion(minVersion)
 
 	switch {
 	case currentMajor != minMajor:
@@ -1,4 +1,4 @@
-package provider
+package capability
 
 import (
 	"testing"
@@ -31,9 +31,9 @@ func TestParseVersion(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		major, minor, patch, beta := parseVersion(test.version)
+		major, minor, patch, beta := ParseVersion(test.version)
 		if major != test.expectedMajor || minor != test.expectedMinor || patch != test.expectedPatch || beta != test.expectedBeta {
-			t.Errorf("parseVersion(%q) = (%d, %d, %d, %d); want (%d, %d, %d, %d)",
+			t.Errorf("ParseVersion(%q) = (%d, %d, %d, %d); want (%d, %d, %d, %d)",
 				test.version, major, minor, patch, beta, test.expectedMajor, test.expectedMinor, test.expectedPatch, test.expectedBeta)
 		}
 	}
@@ -66,9 +66,9 @@ func TestIsVersionCompatible(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		result := isVersionCompatible(test.currentVersion, test.minVersion)
+		result := IsVersionCompatible(test.currentVersion, test.minVersion)
 		if result != test.expected {
-			t.Errorf("isVersionCompatible(%q, %q) = %v; want %v",
+			t.Errorf("IsVersionCompatible(%q, %q) = %v; want %v",
 				test.currentVersion, test.minVersion, result, test.expected)
 		}
 	}
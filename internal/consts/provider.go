@@ -3,6 +3,29 @@ package consts
 const (
 	ENV_KEY_ENDPOINT = "COOLIFY_ENDPOINT"
 	ENV_KEY_TOKEN    = "COOLIFY_TOKEN"
+	ENV_KEY_CONTEXT  = "COOLIFY_CONTEXT"
+
+	ENV_KEY_TOKEN_COMMAND    = "COOLIFY_TOKEN_COMMAND"
+	ENV_KEY_TOKEN_VALIDATION = "COOLIFY_TOKEN_VALIDATION"
+
+	ENV_KEY_REQUEST_TIMEOUT      = "COOLIFY_REQUEST_TIMEOUT"
+	ENV_KEY_CA_CERT_PEM          = "COOLIFY_CA_CERT_PEM"
+	ENV_KEY_CA_CERT_FILE         = "COOLIFY_CA_CERT_FILE"
+	ENV_KEY_INSECURE_SKIP_VERIFY = "COOLIFY_INSECURE_SKIP_VERIFY"
+	ENV_KEY_CLIENT_CERT_PEM      = "COOLIFY_CLIENT_CERT_PEM"
+	ENV_KEY_CLIENT_CERT_FILE     = "COOLIFY_CLIENT_CERT_FILE"
+	ENV_KEY_CLIENT_KEY_PEM       = "COOLIFY_CLIENT_KEY_PEM"
+	ENV_KEY_CLIENT_KEY_FILE      = "COOLIFY_CLIENT_KEY_FILE"
+	ENV_KEY_PROXY_URL            = "COOLIFY_PROXY_URL"
+	ENV_KEY_HEADERS              = "COOLIFY_HEADERS"
+
+	ENV_KEY_MAX_CONCURRENT_REQUESTS = "COOLIFY_MAX_CONCURRENT_REQUESTS"
+	ENV_KEY_CACHE_TTL               = "COOLIFY_CACHE_TTL"
+
+	ENV_KEY_DEFAULT_SERVER_UUID      = "COOLIFY_DEFAULT_SERVER_UUID"
+	ENV_KEY_DEFAULT_PROJECT_UUID     = "COOLIFY_DEFAULT_PROJECT_UUID"
+	ENV_KEY_DEFAULT_ENVIRONMENT_NAME = "COOLIFY_DEFAULT_ENVIRONMENT_NAME"
+	ENV_KEY_ACTION_TIMEOUT           = "COOLIFY_ACTION_TIMEOUT"
 
 	DEFAULT_COOLIFY_ENDPOINT = "https://app.coolify.io/api/v1"
 	MIN_COOLIFY_VERSION      = "4.0.0-beta.381"
@@ -10,4 +33,11 @@ const (
 	DEFAULT_RETRY_ATTEMPTS = 4
 	DEFAULT_RETRY_MIN_WAIT = 1
 	DEFAULT_RETRY_MAX_WAIT = 30
+
+	DEFAULT_REQUEST_TIMEOUT = 30
+	DEFAULT_CACHE_TTL       = 2
+
+	TOKEN_VALIDATION_STRICT = "strict"
+	TOKEN_VALIDATION_WARN   = "warn"
+	TOKEN_VALIDATION_OFF    = "off"
 )
@@ -0,0 +1,194 @@
+// Package dotenv parses and formats the contents of `.env` files.
+//
+// The supported syntax follows the common dotenv conventions: one
+// `KEY=VALUE` pair per line, an optional `export` prefix, `#` comments,
+// and single or double quoted values which may span multiple lines.
+package dotenv
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var keyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+
+var (
+	doubleQuoteUnescaper = map[byte]string{'n': "\n", 'r': "\r", 't': "\t", '"': `"`, '\\': `\`, '$': "$"}
+	doubleQuoteEscaper   = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`)
+)
+
+// Parse parses dotenv content into a map of variables. When a key is defined
+// more than once, the last definition wins.
+//
+// Unquoted values are trimmed and end at a `#` preceded by whitespace. Single
+// quoted values are taken literally, while double quoted values support the
+// `\n`, `\r`, `\t`, `\"`, `\\` and `\$` escape sequences.
+func Parse(content string) (map[string]string, error) {
+	p := &parser{src: strings.ReplaceAll(content, "\r\n", "\n"), line: 1}
+	vars := map[string]string{}
+
+	for !p.eof() {
+		line := p.line
+		key, value, ok, err := p.parseLine()
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if ok {
+			vars[key] = value
+		}
+	}
+
+	return vars, nil
+}
+
+// Format formats variables as dotenv content that Parse reads back unchanged.
+// Keys are sorted and every value is double quoted.
+func Format(vars map[string]string) string {
+	keys := make([]string, 0, len(vars))
+	for key := range vars {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, key := range keys {
+		fmt.Fprintf(&b, "%s=\"%s\"\n", key, doubleQuoteEscaper.Replace(vars[key]))
+	}
+	return b.String()
+}
+
+type parser struct {
+	src  string
+	pos  int
+	line int
+}
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *parser) peek() byte {
+	return p.src[p.pos]
+}
+
+// next consumes a character, keeping track of the current line.
+func (p *parser) next() byte {
+	c := p.src[p.pos]
+	p.pos++
+	if c == '\n' {
+		p.line++
+	}
+	return c
+}
+
+func (p *parser) skipBlanks() {
+	for !p.eof() && (p.peek() == ' ' || p.peek() == '\t') {
+		p.pos++
+	}
+}
+
+// restOfLine consumes the remainder of the current line, excluding the newline.
+func (p *parser) restOfLine() string {
+	start := p.pos
+	for !p.eof() && p.peek() != '\n' {
+		p.pos++
+	}
+	rest := p.src[start:p.pos]
+	if !p.eof() {
+		p.next()
+	}
+	return rest
+}
+
+// parseLine parses a single definition, returning false for blank and comment
+// lines.
+func (p *parser) parseLine() (string, string, bool, error) {
+	p.skipBlanks()
+	if p.eof() {
+		return "", "", false, nil
+	}
+	if p.peek() == '\n' || p.peek() == '#' {
+		p.restOfLine()
+		return "", "", false, nil
+	}
+
+	end := strings.IndexAny(p.src[p.pos:], "=\n")
+	if end < 0 || p.src[p.pos+end] != '=' {
+		p.restOfLine()
+		return "", "", false, errors.New("expected KEY=VALUE")
+	}
+
+	key := strings.TrimSpace(p.src[p.pos : p.pos+end])
+	if rest, ok := strings.CutPrefix(key, "export"); ok && rest != strings.TrimLeft(rest, " \t") {
+		key = strings.TrimSpace(rest)
+	}
+	if !keyPattern.MatchString(key) {
+		return "", "", false, fmt.Errorf("invalid key %q", key)
+	}
+	p.pos += end + 1
+
+	p.skipBlanks()
+	if p.eof() {
+		return key, "", true, nil
+	}
+
+	var value string
+	switch quote := p.peek(); quote {
+	case '"', '\'':
+		p.next()
+		var err error
+		if value, err = p.parseQuoted(quote); err != nil {
+			return "", "", false, err
+		}
+		p.skipBlanks()
+		if !p.eof() && p.peek() != '\n' && p.peek() != '#' {
+			return "", "", false, fmt.Errorf("unexpected characters after quoted value of %q", key)
+		}
+		p.restOfLine()
+	default:
+		value = strings.TrimSpace(stripComment(p.restOfLine()))
+	}
+
+	return key, value, true, nil
+}
+
+// parseQuoted parses a value up to the closing quote, which may be on a later
+// line.
+func (p *parser) parseQuoted(quote byte) (string, error) {
+	var b strings.Builder
+	for !p.eof() {
+		c := p.next()
+		switch {
+		case c == quote:
+			return b.String(), nil
+		case c == '\\' && quote == '"' && !p.eof():
+			if unescaped, ok := doubleQuoteUnescaper[p.peek()]; ok {
+				p.next()
+				b.WriteString(unescaped)
+			} else {
+				b.WriteByte(c)
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	if quote == '"' {
+		return "", errors.New("unterminated double quoted value")
+	}
+	return "", errors.New("unterminated single quoted value")
+}
+
+// stripComment removes a trailing comment from an unquoted value. A `#` only
+// starts a comment at the beginning of the value or after whitespace.
+func stripComment(value string) string {
+	for i := 0; i < len(value); i++ {
+		if value[i] == '#' && (i == 0 || value[i-1] == ' ' || value[i-1] == '\t') {
+			return value[:i]
+		}
+	}
+	return value
+}
@@ -0,0 +1,79 @@
+package dotenv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected map[string]string
+	}{
+		{"empty", "", map[string]string{}},
+		{"blank lines and comments", "\n  \n# comment\n  # indented comment\n", map[string]string{}},
+		{"simple", "FOO=bar\nBAZ=qux", map[string]string{"FOO": "bar", "BAZ": "qux"}},
+		{"crlf", "FOO=bar\r\nBAZ=qux\r\n", map[string]string{"FOO": "bar", "BAZ": "qux"}},
+		{"whitespace", "  FOO =  bar baz  \n", map[string]string{"FOO": "bar baz"}},
+		{"empty value", "FOO=\nBAR=  # comment", map[string]string{"FOO": "", "BAR": ""}},
+		{"export prefix", "export FOO=bar\nexport\tBAR=baz", map[string]string{"FOO": "bar", "BAR": "baz"}},
+		{"key starting with export", "exported=bar", map[string]string{"exported": "bar"}},
+		{"inline comment", "FOO=bar # comment\nBAR=baz#not-a-comment", map[string]string{"FOO": "bar", "BAR": "baz#not-a-comment"}},
+		{"equals in value", "URL=postgres://u:p@host/db?a=b", map[string]string{"URL": "postgres://u:p@host/db?a=b"}},
+		{"double quoted", `FOO="bar # baz" # comment`, map[string]string{"FOO": "bar # baz"}},
+		{"double quoted escapes", `FOO="a\nb\t\"c\"\\d\$e\xf"`, map[string]string{"FOO": "a\nb\t\"c\"\\d$e\\xf"}},
+		{"single quoted", `FOO='bar\n "baz"'`, map[string]string{"FOO": `bar\n "baz"`}},
+		{"multiline double quoted", "KEY=\"-----BEGIN-----\nabc\n-----END-----\"\nNEXT=1", map[string]string{"KEY": "-----BEGIN-----\nabc\n-----END-----", "NEXT": "1"}},
+		{"multiline single quoted", "KEY='line1\nline2'", map[string]string{"KEY": "line1\nline2"}},
+		{"last definition wins", "FOO=1\nFOO=2", map[string]string{"FOO": "2"}},
+		{"dotted and dashed keys", "a.b-c_D=1", map[string]string{"a.b-c_D": "1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vars, err := Parse(tt.content)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, vars)
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{"missing equals", "FOO=1\nBAR\n", "line 2: expected KEY=VALUE"},
+		{"invalid key", "1FOO=bar", `line 1: invalid key "1FOO"`},
+		{"empty key", "=bar", `line 1: invalid key ""`},
+		{"unterminated double quote", "A=1\nFOO=\"bar\nbaz", "line 2: unterminated double quoted value"},
+		{"unterminated single quote", "FOO='bar", "line 1: unterminated single quoted value"},
+		{"trailing characters", `FOO="bar" baz`, `line 1: unexpected characters after quoted value of "FOO"`},
+		{"line after multiline value", "FOO=\"a\nb\"\n\nBAR", "line 4: expected KEY=VALUE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.content)
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	vars := map[string]string{
+		"B":    "multi\nline \"quoted\" \\ value",
+		"A":    "plain",
+		"HASH": "a # b",
+	}
+
+	content := Format(vars)
+	assert.Equal(t, "A=\"plain\"\nB=\"multi\\nline \\\"quoted\\\" \\\\ value\"\nHASH=\"a # b\"\n", content)
+
+	parsed, err := Parse(content)
+	assert.NoError(t, err)
+	assert.Equal(t, vars, parsed)
+}
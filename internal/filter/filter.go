@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
@@ -18,14 +19,23 @@ type BlockModel struct {
 	Values types.List   `tfsdk:"values"`
 }
 
+const (
+	filterDescription       = "Filter results by values"
+	filterValuesDescription = "List of values to match against - if any value matches, the filter is satisfied (**OR** operation). Non-string values will be converted to strings if possible, ie `true` -> `\"true\"`"
+)
+
+func filterNameDescription(allowedFields []string) string {
+	return fmt.Sprintf("Name of the field to filter on. Valid names are `%s`", strings.Join(allowedFields, "`, `"))
+}
+
 // CreateDatasourceFilter creates a filter block for a datasource schema.
 func CreateDatasourceFilter(allowedFields []string) schema.Block {
 	return schema.ListNestedBlock{
-		MarkdownDescription: "Filter results by values",
+		MarkdownDescription: filterDescription,
 		NestedObject: schema.NestedBlockObject{
 			Attributes: map[string]schema.Attribute{
 				"name": schema.StringAttribute{
-					MarkdownDescription: fmt.Sprintf("Name of the field to filter on. Valid names are `%s`", strings.Join(allowedFields, "`, `")),
+					MarkdownDescription: filterNameDescription(allowedFields),
 					Required:            true,
 					Validators: []validator.String{
 						stringvalidator.OneOf(allowedFields...),
@@ -33,7 +43,30 @@ func CreateDatasourceFilter(allowedFields []string) schema.Block {
 				},
 				"values": schema.ListAttribute{
 					Required:            true,
-					MarkdownDescription: "List of values to match against - if any value matches, the filter is satisfied (**OR** operation). Non-string values will be converted to strings if possible, ie `true` -> `\"true\"`",
+					MarkdownDescription: filterValuesDescription,
+					ElementType:         types.StringType,
+				},
+			},
+		},
+	}
+}
+
+// CreateListResourceFilter creates a filter block for a list resource schema.
+func CreateListResourceFilter(allowedFields []string) listschema.Block {
+	return listschema.ListNestedBlock{
+		MarkdownDescription: filterDescription,
+		NestedObject: listschema.NestedBlockObject{
+			Attributes: map[string]listschema.Attribute{
+				"name": listschema.StringAttribute{
+					MarkdownDescription: filterNameDescription(allowedFields),
+					Required:            true,
+					Validators: []validator.String{
+						stringvalidator.OneOf(allowedFields...),
+					},
+				},
+				"values": listschema.ListAttribute{
+					Required:            true,
+					MarkdownDescription: filterValuesDescription,
 					ElementType:         types.StringType,
 				},
 			},
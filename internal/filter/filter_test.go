@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/stretchr/testify/assert"
 )
@@ -350,3 +351,15 @@ func TestCreateDatasourceFilter(t *testing.T) {
 	assert.Contains(t, attributes, "name", "Block should contain 'name' attribute")
 	assert.Contains(t, attributes, "values", "Block should contain 'values' attribute")
 }
+
+func TestCreateListResourceFilter(t *testing.T) {
+	allowedFields := []string{"field1", "field2", "field3"}
+	block := CreateListResourceFilter(allowedFields)
+
+	listBlock, ok := block.(listschema.ListNestedBlock)
+	assert.True(t, ok, "Expected block to be a ListNestedBlock")
+
+	attributes := listBlock.NestedObject.Attributes
+	assert.Contains(t, attributes, "name", "Block should contain 'name' attribute")
+	assert.Contains(t, attributes, "values", "Block should contain 'values' attribute")
+}
@@ -0,0 +1,228 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	"terraform-provider-coolify/internal/api"
+	"terraform-provider-coolify/internal/consts"
+)
+
+// cliConfig is the configuration file of the Coolify CLI, which stores each
+// context as an instance, e.g.
+//
+//	{"instances": [{"name": "prod", "fqdn": "https://coolify.example.com", "token": "1|...", "default": true}]}
+type cliConfig struct {
+	Instances []cliInstance `json:"instances"`
+}
+
+type cliInstance struct {
+	Name    string `json:"name"`
+	Fqdn    string `json:"fqdn"`
+	Token   string `json:"token"`
+	Default bool   `json:"default"`
+}
+
+var (
+	ErrContextNotFound  = errors.New("context not found")
+	ErrNoDefaultContext = errors.New("no default context is set, set `context` to choose one")
+)
+
+// DefaultCLIConfigFile returns where the Coolify CLI stores its contexts.
+func DefaultCLIConfigFile() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "coolify", "config.json")
+}
+
+// GetCredentials resolves the endpoint and token of the provider. Each is
+// taken from the first of:
+//
+//  1. the `endpoint` and `token` arguments
+//  2. the selected Coolify CLI context, if `context`, `config_file` or
+//     COOLIFY_CONTEXT is set
+//  3. the COOLIFY_ENDPOINT and COOLIFY_TOKEN environment variables
+//  4. the default endpoint, Coolify Cloud
+func GetCredentials(data *CoolifyProviderModel, diags *diag.Diagnostics) (string, string) {
+	var cliEndpoint, cliToken string
+	if instance := selectedCLIContext(data, diags); instance != nil {
+		cliEndpoint, cliToken = cliContextEndpoint(instance.Fqdn), instance.Token
+	}
+
+	var endpoint string
+	if !data.Endpoint.IsNull() {
+		endpoint = data.Endpoint.ValueString()
+	} else if cliEndpoint != "" {
+		endpoint = cliEndpoint
+	} else if endpointFromEnv, found := os.LookupEnv(consts.ENV_KEY_ENDPOINT); found {
+		endpoint = endpointFromEnv
+	} else {
+		endpoint = consts.DEFAULT_COOLIFY_ENDPOINT
+	}
+
+	var token string
+	if !data.Token.IsNull() {
+		token = data.Token.ValueString()
+	} else if cliToken != "" {
+		token = cliToken
+	} else if tokenFromEnv, found := os.LookupEnv(consts.ENV_KEY_TOKEN); found {
+		token = tokenFromEnv
+	}
+
+	return endpoint, token
+}
+
+// GetTokenCommand returns the credential helper command to obtain the token
+// with, or nil when the token is set directly.
+func GetTokenCommand(ctx context.Context, data *CoolifyProviderModel, diags *diag.Diagnostics) []string {
+	var command []string
+	if !data.TokenCommand.IsNull() && !data.TokenCommand.IsUnknown() {
+		diags.Append(data.TokenCommand.ElementsAs(ctx, &command, false)...)
+	} else if fromEnv := os.Getenv(consts.ENV_KEY_TOKEN_COMMAND); fromEnv != "" {
+		command = strings.Fields(fromEnv)
+	}
+
+	if len(command) == 0 {
+		return nil
+	}
+	if !data.Token.IsNull() {
+		diags.AddAttributeError(
+			path.Root("token_command"),
+			"Invalid provider configuration",
+			"Only one of `token` and `token_command` can be set.",
+		)
+		return nil
+	}
+	if command[0] == "" {
+		diags.AddAttributeError(path.Root("token_command"), "Invalid provider configuration", "The first element of `token_command` must be the program to run.")
+		return nil
+	}
+
+	return command
+}
+
+// GetTokenValidation returns how strictly the format of the token is checked.
+func GetTokenValidation(data *CoolifyProviderModel, diags *diag.Diagnostics) string {
+	mode, ok := stringSetting(data.TokenValidation, consts.ENV_KEY_TOKEN_VALIDATION)
+	if !ok {
+		return consts.TOKEN_VALIDATION_STRICT
+	}
+
+	switch mode {
+	case consts.TOKEN_VALIDATION_STRICT, consts.TOKEN_VALIDATION_WARN, consts.TOKEN_VALIDATION_OFF:
+		return mode
+	}
+	diags.AddAttributeError(
+		path.Root("token_validation"),
+		"Invalid provider configuration",
+		fmt.Sprintf("`%s` must be one of %q, %q or %q, got: %q", consts.ENV_KEY_TOKEN_VALIDATION, consts.TOKEN_VALIDATION_STRICT, consts.TOKEN_VALIDATION_WARN, consts.TOKEN_VALIDATION_OFF, mode),
+	)
+	return consts.TOKEN_VALIDATION_STRICT
+}
+
+// ValidateToken checks the format of the token according to the validation
+// mode, reporting against the attribute the token was set with.
+func ValidateToken(token, mode string, attribute path.Path, diags *diag.Diagnostics) {
+	if mode == consts.TOKEN_VALIDATION_OFF || api.ValidateTokenFormat(token) == nil {
+		return
+	}
+
+	detail := "The token does not look like a Coolify API token, which is shaped like `3|abc...`."
+	if hint := api.TokenHint(token); hint != "" {
+		detail += " " + hint
+	}
+
+	if mode == consts.TOKEN_VALIDATION_WARN {
+		diags.AddAttributeWarning(attribute, "Unexpected token format", detail+" Continuing, as `token_validation` is set to `"+consts.TOKEN_VALIDATION_WARN+"`.")
+		return
+	}
+	diags.AddAttributeError(attribute, "Invalid token format", detail+" If the token is valid, e.g. because it is issued through a proxy, set `token_validation` to `"+consts.TOKEN_VALIDATION_WARN+"`.")
+}
+
+// MARK: Helper Functions
+
+// selectedCLIContext returns the Coolify CLI context selected by the provider
+// configuration, or nil when none is.
+func selectedCLIContext(data *CoolifyProviderModel, diags *diag.Diagnostics) *cliInstance {
+	contextName, hasContext := stringSetting(data.Context, consts.ENV_KEY_CONTEXT)
+	configFile := data.ConfigFile.ValueString()
+	if !hasContext && configFile == "" {
+		return nil
+	}
+	if configFile == "" {
+		configFile = DefaultCLIConfigFile()
+	}
+
+	instance, err := loadCLIContext(configFile, contextName)
+	if err != nil {
+		attribute := "config_file"
+		if errors.Is(err, ErrContextNotFound) || errors.Is(err, ErrNoDefaultContext) {
+			attribute = "context"
+		}
+		diags.AddAttributeError(
+			path.Root(attribute),
+			"Failed to read Coolify CLI context",
+			fmt.Sprintf("Unable to load context from %s: %s", configFile, err),
+		)
+		return nil
+	}
+
+	if instance.Token == "" && data.Token.IsNull() && data.TokenCommand.IsNull() {
+		diags.AddAttributeError(
+			path.Root("context"),
+			"Failed to read Coolify CLI context",
+			fmt.Sprintf("The context %q in %s has no token. Set one with the Coolify CLI or set `token`.", instance.Name, configFile),
+		)
+	}
+
+	return instance
+}
+
+// loadCLIContext returns the named context from a Coolify CLI configuration
+// file, or the default context when name is empty.
+func loadCLIContext(configFile, name string) (*cliInstance, error) {
+	content, err := os.ReadFile(configFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var config cliConfig
+	if err := json.Unmarshal(content, &config); err != nil {
+		return nil, fmt.Errorf("invalid Coolify CLI configuration: %w", err)
+	}
+
+	names := make([]string, 0, len(config.Instances))
+	for i, instance := range config.Instances {
+		if (name == "" && instance.Default) || (name != "" && instance.Name == name) {
+			return &config.Instances[i], nil
+		}
+		names = append(names, instance.Name)
+	}
+
+	if name == "" {
+		return nil, ErrNoDefaultContext
+	}
+	sort.Strings(names)
+	return nil, fmt.Errorf("%w: %q, available contexts: %s", ErrContextNotFound, name, strings.Join(names, ", "))
+}
+
+// cliContextEndpoint converts the instance URL stored by the Coolify CLI to
+// the API endpoint.
+func cliContextEndpoint(fqdn string) string {
+	fqdn = strings.TrimSuffix(fqdn, "/")
+	if fqdn == "" || strings.HasSuffix(fqdn, "/api/v1") {
+		return fqdn
+	}
+	return fqdn + "/api/v1"
+}
@@ -0,0 +1,263 @@
+package provider_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"terraform-provider-coolify/internal/consts"
+	"terraform-provider-coolify/internal/provider"
+)
+
+func TestGetCredentials(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(configFile, []byte(`{
+		"instances": [
+			{"name": "staging", "fqdn": "https://staging.example.com/", "token": "1|staging"},
+			{"name": "prod", "fqdn": "https://prod.example.com", "token": "2|prod", "default": true},
+			{"name": "empty", "fqdn": "https://empty.example.com", "token": ""}
+		]
+	}`), 0o600))
+
+	noDefaultFile := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(noDefaultFile, []byte(`{"instances": [{"name": "staging", "fqdn": "https://staging.example.com", "token": "1|staging"}]}`), 0o600))
+
+	invalidFile := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(invalidFile, []byte(`instances:`), 0o600))
+
+	testCases := map[string]struct {
+		input            provider.CoolifyProviderModel
+		env              map[string]string
+		expectedEndpoint string
+		expectedToken    string
+		expectedError    bool
+	}{
+		"defaults": {
+			expectedEndpoint: consts.DEFAULT_COOLIFY_ENDPOINT,
+		},
+		"env": {
+			env: map[string]string{
+				consts.ENV_KEY_ENDPOINT: "https://env.example.com/api/v1",
+				consts.ENV_KEY_TOKEN:    "3|env",
+			},
+			expectedEndpoint: "https://env.example.com/api/v1",
+			expectedToken:    "3|env",
+		},
+		"default context": {
+			input:            provider.CoolifyProviderModel{ConfigFile: types.StringValue(configFile)},
+			expectedEndpoint: "https://prod.example.com/api/v1",
+			expectedToken:    "2|prod",
+		},
+		"named context": {
+			input: provider.CoolifyProviderModel{
+				ConfigFile: types.StringValue(configFile),
+				Context:    types.StringValue("staging"),
+			},
+			expectedEndpoint: "https://staging.example.com/api/v1",
+			expectedToken:    "1|staging",
+		},
+		"context from env": {
+			input: provider.CoolifyProviderModel{ConfigFile: types.StringValue(configFile)},
+			env: map[string]string{
+				consts.ENV_KEY_CONTEXT: "staging",
+			},
+			expectedEndpoint: "https://staging.example.com/api/v1",
+			expectedToken:    "1|staging",
+		},
+		"context overrides env": {
+			input: provider.CoolifyProviderModel{
+				ConfigFile: types.StringValue(configFile),
+				Context:    types.StringValue("staging"),
+			},
+			env: map[string]string{
+				consts.ENV_KEY_ENDPOINT: "https://env.example.com/api/v1",
+				consts.ENV_KEY_TOKEN:    "3|env",
+			},
+			expectedEndpoint: "https://staging.example.com/api/v1",
+			expectedToken:    "1|staging",
+		},
+		"arguments override context": {
+			input: provider.CoolifyProviderModel{
+				Endpoint:   types.StringValue("https://arg.example.com/api/v1"),
+				Token:      types.StringValue("4|arg"),
+				ConfigFile: types.StringValue(configFile),
+				Context:    types.StringValue("staging"),
+			},
+			expectedEndpoint: "https://arg.example.com/api/v1",
+			expectedToken:    "4|arg",
+		},
+		"context without token": {
+			input: provider.CoolifyProviderModel{
+				ConfigFile: types.StringValue(configFile),
+				Context:    types.StringValue("empty"),
+			},
+			expectedEndpoint: "https://empty.example.com/api/v1",
+			expectedError:    true,
+		},
+		"unknown context": {
+			input: provider.CoolifyProviderModel{
+				ConfigFile: types.StringValue(configFile),
+				Context:    types.StringValue("missing"),
+			},
+			expectedEndpoint: consts.DEFAULT_COOLIFY_ENDPOINT,
+			expectedError:    true,
+		},
+		"no default context": {
+			input:            provider.CoolifyProviderModel{ConfigFile: types.StringValue(noDefaultFile)},
+			expectedEndpoint: consts.DEFAULT_COOLIFY_ENDPOINT,
+			expectedError:    true,
+		},
+		"invalid config file": {
+			input:            provider.CoolifyProviderModel{ConfigFile: types.StringValue(invalidFile)},
+			expectedEndpoint: consts.DEFAULT_COOLIFY_ENDPOINT,
+			expectedError:    true,
+		},
+		"missing config file": {
+			input:            provider.CoolifyProviderModel{ConfigFile: types.StringValue(filepath.Join(t.TempDir(), "missing.json"))},
+			expectedEndpoint: consts.DEFAULT_COOLIFY_ENDPOINT,
+			expectedError:    true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// Unset rather than empty, as an empty endpoint is used as is
+			t.Setenv(consts.ENV_KEY_CONTEXT, "")
+			for _, key := range []string{consts.ENV_KEY_ENDPOINT, consts.ENV_KEY_TOKEN} {
+				t.Setenv(key, "")
+				require.NoError(t, os.Unsetenv(key))
+			}
+			for key, value := range tc.env {
+				t.Setenv(key, value)
+			}
+
+			var diags diag.Diagnostics
+			endpoint, token := provider.GetCredentials(&tc.input, &diags)
+
+			assert.Equal(t, tc.expectedError, diags.HasError(), diags)
+			assert.Equal(t, tc.expectedEndpoint, endpoint)
+			assert.Equal(t, tc.expectedToken, token)
+		})
+	}
+}
+
+func TestGetTokenCommand(t *testing.T) {
+	testCases := map[string]struct {
+		input         provider.CoolifyProviderModel
+		env           map[string]string
+		expected      []string
+		expectedError bool
+	}{
+		"not set": {
+			input: provider.CoolifyProviderModel{TokenCommand: types.ListNull(types.StringType)},
+		},
+		"argument": {
+			input: provider.CoolifyProviderModel{
+				TokenCommand: types.ListValueMust(types.StringType, []attr.Value{
+					types.StringValue("op"), types.StringValue("read"), types.StringValue("op://ci/coolify/token"),
+				}),
+			},
+			env:      map[string]string{consts.ENV_KEY_TOKEN_COMMAND: "vault read"},
+			expected: []string{"op", "read", "op://ci/coolify/token"},
+		},
+		"env": {
+			input:    provider.CoolifyProviderModel{TokenCommand: types.ListNull(types.StringType)},
+			env:      map[string]string{consts.ENV_KEY_TOKEN_COMMAND: "vault kv get -field=token secret/coolify"},
+			expected: []string{"vault", "kv", "get", "-field=token", "secret/coolify"},
+		},
+		"conflicts with token": {
+			input: provider.CoolifyProviderModel{
+				Token:        types.StringValue("1|token"),
+				TokenCommand: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("op")}),
+			},
+			expectedError: true,
+		},
+		"empty program": {
+			input: provider.CoolifyProviderModel{
+				TokenCommand: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("")}),
+			},
+			expectedError: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(consts.ENV_KEY_TOKEN_COMMAND, "")
+			for key, value := range tc.env {
+				t.Setenv(key, value)
+			}
+
+			var diags diag.Diagnostics
+			result := provider.GetTokenCommand(context.Background(), &tc.input, &diags)
+
+			assert.Equal(t, tc.expectedError, diags.HasError(), diags)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestValidateToken(t *testing.T) {
+	testCases := map[string]struct {
+		token           string
+		mode            string
+		expectedError   bool
+		expectedWarning bool
+	}{
+		"strict valid":   {token: "1|token", mode: consts.TOKEN_VALIDATION_STRICT},
+		"strict invalid": {token: "proxy-token", mode: consts.TOKEN_VALIDATION_STRICT, expectedError: true},
+		"warn valid":     {token: "1|token", mode: consts.TOKEN_VALIDATION_WARN},
+		"warn invalid":   {token: "proxy-token", mode: consts.TOKEN_VALIDATION_WARN, expectedWarning: true},
+		"off invalid":    {token: "proxy-token", mode: consts.TOKEN_VALIDATION_OFF},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			provider.ValidateToken(tc.token, tc.mode, path.Root("token"), &diags)
+
+			assert.Equal(t, tc.expectedError, diags.HasError(), diags)
+			assert.Equal(t, tc.expectedWarning, diags.WarningsCount() > 0, diags)
+		})
+	}
+
+	t.Run("hint", func(t *testing.T) {
+		var diags diag.Diagnostics
+		provider.ValidateToken("Bearer 1|token", consts.TOKEN_VALIDATION_STRICT, path.Root("token"), &diags)
+		require.True(t, diags.HasError())
+		assert.Contains(t, diags.Errors()[0].Detail(), "`Bearer `")
+	})
+}
+
+func TestGetTokenValidation(t *testing.T) {
+	testCases := map[string]struct {
+		input         types.String
+		env           string
+		expected      string
+		expectedError bool
+	}{
+		"default":     {input: types.StringNull(), expected: consts.TOKEN_VALIDATION_STRICT},
+		"argument":    {input: types.StringValue(consts.TOKEN_VALIDATION_OFF), env: consts.TOKEN_VALIDATION_WARN, expected: consts.TOKEN_VALIDATION_OFF},
+		"env":         {input: types.StringNull(), env: consts.TOKEN_VALIDATION_WARN, expected: consts.TOKEN_VALIDATION_WARN},
+		"invalid env": {input: types.StringNull(), env: "lenient", expected: consts.TOKEN_VALIDATION_STRICT, expectedError: true},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(consts.ENV_KEY_TOKEN_VALIDATION, tc.env)
+
+			var diags diag.Diagnostics
+			result := provider.GetTokenValidation(&provider.CoolifyProviderModel{TokenValidation: tc.input}, &diags)
+
+			assert.Equal(t, tc.expectedError, diags.HasError(), diags)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
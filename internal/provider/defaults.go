@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	"terraform-provider-coolify/internal/consts"
+	"terraform-provider-coolify/internal/provider/util"
+)
+
+// GetDefaults resolves where resources are placed when they do not set it
+// themselves, falling back to the environment for each value.
+func GetDefaults(data *CoolifyProviderModel) util.Defaults {
+	var config DefaultsModel
+	if data.Defaults != nil {
+		config = *data.Defaults
+	}
+
+	var defaults util.Defaults
+	defaults.ServerUuid, _ = stringSetting(config.ServerUuid, consts.ENV_KEY_DEFAULT_SERVER_UUID)
+	defaults.ProjectUuid, _ = stringSetting(config.ProjectUuid, consts.ENV_KEY_DEFAULT_PROJECT_UUID)
+	defaults.EnvironmentName, _ = stringSetting(config.EnvironmentName, consts.ENV_KEY_DEFAULT_ENVIRONMENT_NAME)
+
+	return defaults
+}
+
+// GetActionTimeout resolves how long actions wait by default, zero when not
+// set so actions use their own default.
+func GetActionTimeout(data *CoolifyProviderModel, diags *diag.Diagnostics) time.Duration {
+	timeout, ok := stringSetting(data.ActionTimeout, consts.ENV_KEY_ACTION_TIMEOUT)
+	if !ok {
+		return 0
+	}
+
+	duration, err := time.ParseDuration(timeout)
+	if err != nil || duration <= 0 {
+		source := "`action_timeout`"
+		if data.ActionTimeout.IsNull() {
+			source = "`" + consts.ENV_KEY_ACTION_TIMEOUT + "`"
+		}
+		diags.AddAttributeError(
+			path.Root("action_timeout"),
+			"Invalid provider configuration",
+			fmt.Sprintf("%s must be a positive duration such as \"30s\" or \"5m\", got: %q", source, timeout),
+		)
+		return 0
+	}
+
+	return duration
+}
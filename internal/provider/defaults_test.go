@@ -0,0 +1,104 @@
+package provider_test
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+
+	"terraform-provider-coolify/internal/consts"
+	"terraform-provider-coolify/internal/provider"
+	"terraform-provider-coolify/internal/provider/util"
+)
+
+func TestGetDefaults(t *testing.T) {
+	testCases := map[string]struct {
+		input    provider.CoolifyProviderModel
+		env      map[string]string
+		expected util.Defaults
+	}{
+		"not set": {},
+		"argument overrides env": {
+			input: provider.CoolifyProviderModel{
+				Defaults: &provider.DefaultsModel{
+					ServerUuid:  types.StringValue("server-arg"),
+					ProjectUuid: types.StringValue("project-arg"),
+				},
+			},
+			env: map[string]string{
+				consts.ENV_KEY_DEFAULT_SERVER_UUID:      "server-env",
+				consts.ENV_KEY_DEFAULT_ENVIRONMENT_NAME: "staging",
+			},
+			expected: util.Defaults{ServerUuid: "server-arg", ProjectUuid: "project-arg", EnvironmentName: "staging"},
+		},
+		"env fallback": {
+			env: map[string]string{
+				consts.ENV_KEY_DEFAULT_SERVER_UUID:      "server-env",
+				consts.ENV_KEY_DEFAULT_PROJECT_UUID:     "project-env",
+				consts.ENV_KEY_DEFAULT_ENVIRONMENT_NAME: "production",
+			},
+			expected: util.Defaults{ServerUuid: "server-env", ProjectUuid: "project-env", EnvironmentName: "production"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			for _, key := range []string{consts.ENV_KEY_DEFAULT_SERVER_UUID, consts.ENV_KEY_DEFAULT_PROJECT_UUID, consts.ENV_KEY_DEFAULT_ENVIRONMENT_NAME} {
+				t.Setenv(key, "")
+				os.Unsetenv(key)
+			}
+			for key, value := range tc.env {
+				t.Setenv(key, value)
+			}
+
+			assert.Equal(t, tc.expected, provider.GetDefaults(&tc.input))
+		})
+	}
+}
+
+func TestGetActionTimeout(t *testing.T) {
+	testCases := map[string]struct {
+		input         provider.CoolifyProviderModel
+		env           map[string]string
+		expected      time.Duration
+		expectedError bool
+	}{
+		"not set": {},
+		"argument overrides env": {
+			input:    provider.CoolifyProviderModel{ActionTimeout: types.StringValue("30m")},
+			env:      map[string]string{consts.ENV_KEY_ACTION_TIMEOUT: "5m"},
+			expected: 30 * time.Minute,
+		},
+		"env fallback": {
+			env:      map[string]string{consts.ENV_KEY_ACTION_TIMEOUT: "5m"},
+			expected: 5 * time.Minute,
+		},
+		"invalid duration": {
+			input:         provider.CoolifyProviderModel{ActionTimeout: types.StringValue("5")},
+			expectedError: true,
+		},
+		"negative env": {
+			env:           map[string]string{consts.ENV_KEY_ACTION_TIMEOUT: "-1m"},
+			expectedError: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(consts.ENV_KEY_ACTION_TIMEOUT, "")
+			os.Unsetenv(consts.ENV_KEY_ACTION_TIMEOUT)
+			for key, value := range tc.env {
+				t.Setenv(key, value)
+			}
+
+			var diags diag.Diagnostics
+			result := provider.GetActionTimeout(&tc.input, &diags)
+
+			assert.Equal(t, tc.expectedError, diags.HasError(), diags)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
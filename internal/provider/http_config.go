@@ -0,0 +1,212 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-coolify/internal/api"
+	"terraform-provider-coolify/internal/consts"
+)
+
+// reservedHeaders are set by the client itself and cannot be overridden by
+// the `headers` argument.
+var reservedHeaders = []string{"Authorization", "User-Agent", "Accept"}
+
+// GetHTTPConfig resolves the HTTP arguments of the provider, falling back to
+// their environment variables, and reports invalid combinations.
+func GetHTTPConfig(ctx context.Context, data *CoolifyProviderModel, diags *diag.Diagnostics) api.HTTPConfig {
+	var config api.HTTPConfig
+
+	if timeout, ok := int64Setting(data.RequestTimeout, consts.ENV_KEY_REQUEST_TIMEOUT, "request_timeout", diags); ok {
+		if timeout <= 0 {
+			diags.AddAttributeError(path.Root("request_timeout"), "Invalid provider configuration", "`request_timeout` must be a positive number of seconds.")
+		}
+		config.Timeout = time.Duration(timeout) * time.Second
+	} else {
+		config.Timeout = consts.DEFAULT_REQUEST_TIMEOUT * time.Second
+	}
+
+	config.CACertPEM = pemSetting(data.CACertPem, data.CACertFile, consts.ENV_KEY_CA_CERT_PEM, consts.ENV_KEY_CA_CERT_FILE, "ca_cert", diags)
+	config.ClientCertPEM = pemSetting(data.ClientCertPem, data.ClientCertFile, consts.ENV_KEY_CLIENT_CERT_PEM, consts.ENV_KEY_CLIENT_CERT_FILE, "client_cert", diags)
+	config.ClientKeyPEM = pemSetting(data.ClientKeyPem, data.ClientKeyFile, consts.ENV_KEY_CLIENT_KEY_PEM, consts.ENV_KEY_CLIENT_KEY_FILE, "client_key", diags)
+
+	if (len(config.ClientCertPEM) > 0) != (len(config.ClientKeyPEM) > 0) {
+		diags.AddError(
+			"Invalid provider configuration",
+			"A client certificate and key must be set together for mutual TLS, using `client_cert_pem` or `client_cert_file` and `client_key_pem` or `client_key_file`.",
+		)
+	}
+
+	if insecure, ok := boolSetting(data.InsecureSkipVerify, consts.ENV_KEY_INSECURE_SKIP_VERIFY, "insecure_skip_verify", diags); ok {
+		config.InsecureSkipVerify = insecure
+	}
+	if config.InsecureSkipVerify && len(config.CACertPEM) > 0 {
+		diags.AddAttributeError(
+			path.Root("insecure_skip_verify"),
+			"Invalid provider configuration",
+			"`insecure_skip_verify` disables certificate verification, so a CA certificate set with `ca_cert_pem` or `ca_cert_file` would be ignored. Set only one of them.",
+		)
+	}
+
+	if proxyUrl, ok := stringSetting(data.ProxyUrl, consts.ENV_KEY_PROXY_URL); ok {
+		parsed, err := url.Parse(proxyUrl)
+		if err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https" && parsed.Scheme != "socks5") {
+			diags.AddAttributeError(
+				path.Root("proxy_url"),
+				"Invalid provider configuration",
+				"`proxy_url` must be an http, https or socks5 URL, e.g. `http://proxy.example.com:3128`.",
+			)
+		} else {
+			config.ProxyURL = parsed
+		}
+	}
+
+	config.Headers = headersSetting(ctx, data.Headers, diags)
+
+	if maxRequests, ok := int64Setting(data.MaxConcurrentRequests, consts.ENV_KEY_MAX_CONCURRENT_REQUESTS, "max_concurrent_requests", diags); ok {
+		if maxRequests <= 0 {
+			diags.AddAttributeError(path.Root("max_concurrent_requests"), "Invalid provider configuration", "`max_concurrent_requests` must be a positive number.")
+		}
+		config.MaxConcurrentRequests = maxRequests
+	}
+
+	if cacheTtl, ok := int64Setting(data.CacheTtl, consts.ENV_KEY_CACHE_TTL, "cache_ttl", diags); ok {
+		if cacheTtl < 0 {
+			diags.AddAttributeError(path.Root("cache_ttl"), "Invalid provider configuration", "`cache_ttl` must be zero, to disable caching, or a positive number of seconds.")
+		}
+		config.CacheTTL = time.Duration(cacheTtl) * time.Second
+	} else {
+		config.CacheTTL = consts.DEFAULT_CACHE_TTL * time.Second
+	}
+
+	return config
+}
+
+// MARK: Helper Functions
+
+// stringSetting returns the value of an argument, or its environment variable
+// when the argument is not set.
+func stringSetting(value types.String, envKey string) (string, bool) {
+	if !value.IsNull() && !value.IsUnknown() {
+		return value.ValueString(), true
+	}
+	if fromEnv := os.Getenv(envKey); fromEnv != "" {
+		return fromEnv, true
+	}
+	return "", false
+}
+
+func boolSetting(value types.Bool, envKey, attribute string, diags *diag.Diagnostics) (bool, bool) {
+	if !value.IsNull() && !value.IsUnknown() {
+		return value.ValueBool(), true
+	}
+	if fromEnv := os.Getenv(envKey); fromEnv != "" {
+		parsed, err := strconv.ParseBool(fromEnv)
+		if err != nil {
+			diags.AddAttributeError(path.Root(attribute), "Invalid provider configuration", fmt.Sprintf("`%s` must be a boolean, got: %q", envKey, fromEnv))
+			return false, false
+		}
+		return parsed, true
+	}
+	return false, false
+}
+
+func int64Setting(value types.Int64, envKey, attribute string, diags *diag.Diagnostics) (int64, bool) {
+	if !value.IsNull() && !value.IsUnknown() {
+		return value.ValueInt64(), true
+	}
+	if fromEnv := os.Getenv(envKey); fromEnv != "" {
+		parsed, err := strconv.ParseInt(fromEnv, 10, 64)
+		if err != nil {
+			diags.AddAttributeError(path.Root(attribute), "Invalid provider configuration", fmt.Sprintf("`%s` must be a whole number, got: %q", envKey, fromEnv))
+			return 0, false
+		}
+		return parsed, true
+	}
+	return 0, false
+}
+
+// pemSetting returns PEM content set inline with `<name>_pem` or read from
+// `<name>_file`. Arguments take precedence over environment variables as a
+// pair, so setting either argument ignores both environment variables.
+func pemSetting(pemValue, fileValue types.String, pemEnvKey, fileEnvKey, name string, diags *diag.Diagnostics) []byte {
+	pemAttribute, fileAttribute := name+"_pem", name+"_file"
+
+	pem, file := pemValue.ValueString(), fileValue.ValueString()
+	if pem == "" && file == "" {
+		pem, file = os.Getenv(pemEnvKey), os.Getenv(fileEnvKey)
+	}
+
+	if pem != "" && file != "" {
+		diags.AddAttributeError(
+			path.Root(fileAttribute),
+			"Invalid provider configuration",
+			fmt.Sprintf("Only one of `%s` and `%s` can be set.", pemAttribute, fileAttribute),
+		)
+		return nil
+	}
+
+	if file != "" {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			diags.AddAttributeError(path.Root(fileAttribute), "Invalid provider configuration", fmt.Sprintf("Unable to read `%s`: %s", fileAttribute, err))
+			return nil
+		}
+		return content
+	}
+
+	if pem != "" {
+		return []byte(pem)
+	}
+	return nil
+}
+
+// headersSetting returns the `headers` argument, or the headers set in the
+// environment variable as comma separated `Name=value` pairs.
+func headersSetting(ctx context.Context, value types.Map, diags *diag.Diagnostics) map[string]string {
+	headers := map[string]string{}
+
+	if !value.IsNull() && !value.IsUnknown() {
+		diags.Append(value.ElementsAs(ctx, &headers, false)...)
+	} else if fromEnv := os.Getenv(consts.ENV_KEY_HEADERS); fromEnv != "" {
+		for _, pair := range strings.Split(fromEnv, ",") {
+			name, headerValue, ok := strings.Cut(pair, "=")
+			if !ok || strings.TrimSpace(name) == "" {
+				diags.AddAttributeError(
+					path.Root("headers"),
+					"Invalid provider configuration",
+					fmt.Sprintf("`%s` must be comma separated `Name=value` pairs, got: %q", consts.ENV_KEY_HEADERS, pair),
+				)
+				continue
+			}
+			headers[strings.TrimSpace(name)] = strings.TrimSpace(headerValue)
+		}
+	}
+
+	for name := range headers {
+		for _, reserved := range reservedHeaders {
+			if http.CanonicalHeaderKey(name) == reserved {
+				diags.AddAttributeError(
+					path.Root("headers"),
+					"Invalid provider configuration",
+					fmt.Sprintf("The %s header is set by the provider and cannot be overridden.", reserved),
+				)
+			}
+		}
+	}
+
+	if len(headers) == 0 {
+		return nil
+	}
+	return headers
+}
@@ -0,0 +1,161 @@
+package provider_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"terraform-provider-coolify/internal/api"
+	"terraform-provider-coolify/internal/consts"
+	"terraform-provider-coolify/internal/provider"
+)
+
+func TestGetHTTPConfig(t *testing.T) {
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, []byte("ca from file"), 0o600))
+
+	testCases := map[string]struct {
+		input         provider.CoolifyProviderModel
+		env           map[string]string
+		expectedError bool
+		check         func(t *testing.T, config api.HTTPConfig)
+	}{
+		"defaults": {
+			check: func(t *testing.T, config api.HTTPConfig) {
+				assert.Equal(t, time.Duration(consts.DEFAULT_REQUEST_TIMEOUT)*time.Second, config.Timeout)
+				assert.Nil(t, config.CACertPEM)
+				assert.Nil(t, config.Headers)
+				assert.Zero(t, config.MaxConcurrentRequests)
+				assert.Equal(t, time.Duration(consts.DEFAULT_CACHE_TTL)*time.Second, config.CacheTTL)
+			},
+		},
+		"argument overrides env": {
+			input: provider.CoolifyProviderModel{
+				RequestTimeout: types.Int64Value(5),
+				CACertPem:      types.StringValue("ca from argument"),
+			},
+			env: map[string]string{
+				consts.ENV_KEY_REQUEST_TIMEOUT: "60",
+				consts.ENV_KEY_CA_CERT_FILE:    caFile,
+			},
+			check: func(t *testing.T, config api.HTTPConfig) {
+				assert.Equal(t, 5*time.Second, config.Timeout)
+				assert.Equal(t, []byte("ca from argument"), config.CACertPEM)
+			},
+		},
+		"env fallback": {
+			env: map[string]string{
+				consts.ENV_KEY_REQUEST_TIMEOUT: "60",
+				consts.ENV_KEY_CA_CERT_FILE:    caFile,
+				consts.ENV_KEY_HEADERS:         "X-Team=platform, CF-Access-Client-Id=abc",
+			},
+			check: func(t *testing.T, config api.HTTPConfig) {
+				assert.Equal(t, time.Minute, config.Timeout)
+				assert.Equal(t, []byte("ca from file"), config.CACertPEM)
+				assert.Equal(t, map[string]string{"X-Team": "platform", "CF-Access-Client-Id": "abc"}, config.Headers)
+			},
+		},
+		"pem and file": {
+			input: provider.CoolifyProviderModel{
+				CACertPem:  types.StringValue("ca"),
+				CACertFile: types.StringValue(caFile),
+			},
+			expectedError: true,
+		},
+		"missing file": {
+			input: provider.CoolifyProviderModel{
+				CACertFile: types.StringValue(filepath.Join(t.TempDir(), "missing.pem")),
+			},
+			expectedError: true,
+		},
+		"client cert without key": {
+			input: provider.CoolifyProviderModel{
+				ClientCertPem: types.StringValue("cert"),
+			},
+			expectedError: true,
+		},
+		"insecure with ca": {
+			input: provider.CoolifyProviderModel{
+				CACertPem:          types.StringValue("ca"),
+				InsecureSkipVerify: types.BoolValue(true),
+			},
+			expectedError: true,
+		},
+		"invalid proxy": {
+			input: provider.CoolifyProviderModel{
+				ProxyUrl: types.StringValue("proxy.example.com:3128"),
+			},
+			expectedError: true,
+		},
+		"reserved header": {
+			input: provider.CoolifyProviderModel{
+				Headers: types.MapValueMust(types.StringType, map[string]attr.Value{
+					"authorization": types.StringValue("Bearer other"),
+				}),
+			},
+			expectedError: true,
+		},
+		"invalid header env": {
+			env:           map[string]string{consts.ENV_KEY_HEADERS: "X-Team"},
+			expectedError: true,
+		},
+		"invalid timeout env": {
+			env:           map[string]string{consts.ENV_KEY_REQUEST_TIMEOUT: "30s"},
+			expectedError: true,
+		},
+		"max concurrent requests env": {
+			env: map[string]string{consts.ENV_KEY_MAX_CONCURRENT_REQUESTS: "2"},
+			check: func(t *testing.T, config api.HTTPConfig) {
+				assert.EqualValues(t, 2, config.MaxConcurrentRequests)
+			},
+		},
+		"zero max concurrent requests": {
+			input: provider.CoolifyProviderModel{
+				MaxConcurrentRequests: types.Int64Value(0),
+			},
+			expectedError: true,
+		},
+		"cache disabled": {
+			env: map[string]string{consts.ENV_KEY_CACHE_TTL: "0"},
+			check: func(t *testing.T, config api.HTTPConfig) {
+				assert.Zero(t, config.CacheTTL)
+			},
+		},
+		"negative cache ttl": {
+			input: provider.CoolifyProviderModel{
+				CacheTtl: types.Int64Value(-1),
+			},
+			expectedError: true,
+		},
+		"zero timeout": {
+			input: provider.CoolifyProviderModel{
+				RequestTimeout: types.Int64Value(0),
+			},
+			expectedError: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			for key, value := range tc.env {
+				t.Setenv(key, value)
+			}
+
+			var diags diag.Diagnostics
+			result := provider.GetHTTPConfig(context.Background(), &tc.input, &diags)
+
+			assert.Equal(t, tc.expectedError, diags.HasError(), diags)
+			if tc.check != nil {
+				tc.check(t, result)
+			}
+		})
+	}
+}
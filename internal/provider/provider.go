@@ -4,12 +4,14 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"os"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -19,7 +21,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"terraform-provider-coolify/internal/api"
+	"terraform-provider-coolify/internal/capability"
 	"terraform-provider-coolify/internal/consts"
+	"terraform-provider-coolify/internal/provider/util"
 	"terraform-provider-coolify/internal/service"
 	"terraform-provider-coolify/internal/service/private_key"
 	service_ds "terraform-provider-coolify/internal/service/service"
@@ -30,6 +34,8 @@ var (
 	_ provider.Provider                       = &CoolifyProvider{}
 	_ provider.ProviderWithFunctions          = &CoolifyProvider{}
 	_ provider.ProviderWithEphemeralResources = &CoolifyProvider{}
+	_ provider.ProviderWithListResources      = &CoolifyProvider{}
+	_ provider.ProviderWithActions            = &CoolifyProvider{}
 )
 
 // CoolifyProvider defines the provider implementation.
@@ -44,6 +50,36 @@ type CoolifyProviderModel struct {
 	Endpoint types.String      `tfsdk:"endpoint"`
 	Token    types.String      `tfsdk:"token"`
 	Retry    *RetryConfigModel `tfsdk:"retry"`
+
+	ConfigFile types.String `tfsdk:"config_file"`
+	Context    types.String `tfsdk:"context"`
+
+	TokenCommand    types.List   `tfsdk:"token_command"`
+	TokenValidation types.String `tfsdk:"token_validation"`
+	TeamTokens      types.Map    `tfsdk:"team_tokens"`
+
+	RequestTimeout     types.Int64  `tfsdk:"request_timeout"`
+	CACertPem          types.String `tfsdk:"ca_cert_pem"`
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	ClientCertPem      types.String `tfsdk:"client_cert_pem"`
+	ClientCertFile     types.String `tfsdk:"client_cert_file"`
+	ClientKeyPem       types.String `tfsdk:"client_key_pem"`
+	ClientKeyFile      types.String `tfsdk:"client_key_file"`
+	ProxyUrl           types.String `tfsdk:"proxy_url"`
+	Headers            types.Map    `tfsdk:"headers"`
+
+	MaxConcurrentRequests types.Int64 `tfsdk:"max_concurrent_requests"`
+	CacheTtl              types.Int64 `tfsdk:"cache_ttl"`
+
+	Defaults      *DefaultsModel `tfsdk:"defaults"`
+	ActionTimeout types.String   `tfsdk:"action_timeout"`
+}
+
+type DefaultsModel struct {
+	ServerUuid      types.String `tfsdk:"server_uuid"`
+	ProjectUuid     types.String `tfsdk:"project_uuid"`
+	EnvironmentName types.String `tfsdk:"environment_name"`
 }
 
 type RetryConfigModel struct {
@@ -66,29 +102,66 @@ func (p *CoolifyProvider) Metadata(ctx context.Context, req provider.MetadataReq
 }
 
 func (p *CoolifyProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
-	hasEnvToken := os.Getenv(consts.ENV_KEY_TOKEN) != ""
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "" +
 			"The \"coolify\" provider facilitates interaction with resources supported by [Coolify](https://coolify.io/) v" + consts.MIN_COOLIFY_VERSION + " and later.\n\n" +
 			"Before using this provider, you must configure it with your credentials, typically by setting the environment variable `" + consts.ENV_KEY_TOKEN + "`.\n\n" +
+			"If you use the [Coolify CLI](https://github.com/coollabsio/coolify-cli), the provider can instead read the endpoint and token of one of its contexts by setting `context` or `" + consts.ENV_KEY_CONTEXT + "`. " +
+			"The `endpoint` and `token` arguments take precedence over the selected context, which takes precedence over the `" + consts.ENV_KEY_ENDPOINT + "` and `" + consts.ENV_KEY_TOKEN + "` environment variables.\n\n" +
 			"For instructions on obtaining an API token, refer to Coolify's [API documentation](https://coolify.io/docs/api-reference/authorization#generate).",
 		Attributes: map[string]schema.Attribute{
 			"endpoint": schema.StringAttribute{
 				Optional:    true,
-				Description: "Coolify endpoint. If not set, checks env for `" + consts.ENV_KEY_ENDPOINT + "`. Default: `" + consts.DEFAULT_COOLIFY_ENDPOINT + "`.",
+				Description: "Coolify endpoint. If not set, uses the selected Coolify CLI context, then checks env for `" + consts.ENV_KEY_ENDPOINT + "`. Default: `" + consts.DEFAULT_COOLIFY_ENDPOINT + "`.",
 			},
 			"token": schema.StringAttribute{
-				Required:  !hasEnvToken,
-				Optional:  hasEnvToken,
-				Sensitive: true,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Coolify token. If not set, uses the selected Coolify CLI context, then checks env for `" + consts.ENV_KEY_TOKEN + "`.",
+			},
+			"token_command": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				Description: "Command to run to obtain the token instead of setting `token`, e.g. `[\"op\", \"read\", \"op://ci/coolify/token\"]`. " +
+					"The command prints the token, or a JSON object such as `{\"token\": \"...\", \"expires_at\": \"2025-01-01T00:00:00Z\"}`. " +
+					"The token is reused until it expires, and the command is run again when Coolify rejects the token. " +
+					"Takes precedence over the selected Coolify CLI context and `" + consts.ENV_KEY_TOKEN + "`. Conflicts with `token`. " +
+					"If not set, checks env for `" + consts.ENV_KEY_TOKEN_COMMAND + "`, split on whitespace.",
+			},
+			"token_validation": schema.StringAttribute{
+				Optional: true,
 				Validators: []validator.String{
-					stringvalidator.RegexMatches(api.TokenRegex, api.ErrInvalidToken.Error()),
+					stringvalidator.OneOf(consts.TOKEN_VALIDATION_STRICT, consts.TOKEN_VALIDATION_WARN, consts.TOKEN_VALIDATION_OFF),
 				},
-				Description: "Coolify token. If not set, checks env for `" + consts.ENV_KEY_TOKEN + "`.",
+				Description: "How to check the format of the token before using it. " +
+					"`" + consts.TOKEN_VALIDATION_STRICT + "` rejects tokens not shaped like a Coolify API token, e.g. `3|abc...`. " +
+					"`" + consts.TOKEN_VALIDATION_WARN + "` only warns, for tokens issued through a proxy or by future Coolify versions, and relies on Coolify accepting the token. " +
+					"`" + consts.TOKEN_VALIDATION_OFF + "` skips the check. " +
+					"If not set, checks env for `" + consts.ENV_KEY_TOKEN_VALIDATION + "`. Default: `" + consts.TOKEN_VALIDATION_STRICT + "`.",
+			},
+			"team_tokens": schema.MapAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				ElementType: types.StringType,
+				Description: "Tokens of other teams, keyed by team ID, e.g. `{\"3\" = \"3|abc...\"}`. " +
+					"Resources and the start, stop, restart and deploy actions accept a `team_id`, and are managed with the token of that team. " +
+					"Data sources and list resources always use `token`. " +
+					"Each token is checked to belong to its team when configuring the provider, and the provider refuses to run if one does not.",
+			},
+			"config_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to the Coolify CLI configuration file to read contexts from. Setting it without `context` selects the default context of the file. Default: `~/.config/coolify/config.json`.",
+			},
+			"context": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of the Coolify CLI context to read the endpoint and token from. If not set, checks env for `" + consts.ENV_KEY_CONTEXT + "`.",
 			},
 			"retry": schema.SingleNestedAttribute{
 				Optional:    true,
-				Description: "Configuration for the HTTP retry behavior",
+				Description: "Configuration for the HTTP retry behavior. Rate limited requests wait as long as Coolify asks via the `Retry-After` or `X-RateLimit-Reset` headers, up to `max_wait`. Requests that are not idempotent, such as creating resources or starting, stopping, restarting and deploying them, are only retried when the connection fails before the request is sent.",
 				Attributes: map[string]schema.Attribute{
 					"attempts": schema.Int64Attribute{
 						Optional:    true,
@@ -100,10 +173,84 @@ func (p *CoolifyProvider) Schema(ctx context.Context, req provider.SchemaRequest
 					},
 					"max_wait": schema.Int64Attribute{
 						Optional:    true,
-						Description: fmt.Sprintf("Maximum time to wait between retries in seconds. Default: %d", consts.DEFAULT_RETRY_MAX_WAIT),
+						Description: fmt.Sprintf("Maximum time to wait between retries in seconds, including when rate limited. Default: %d", consts.DEFAULT_RETRY_MAX_WAIT),
 					},
 				},
 			},
+			"request_timeout": schema.Int64Attribute{
+				Optional:    true,
+				Description: fmt.Sprintf("Timeout in seconds for each HTTP request attempt. If not set, checks env for `%s`. Default: %d", consts.ENV_KEY_REQUEST_TIMEOUT, consts.DEFAULT_REQUEST_TIMEOUT),
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				Optional:    true,
+				Description: "PEM encoded CA certificate to trust in addition to the system CAs, e.g. for an internal CA. Conflicts with `ca_cert_file`. If neither is set, checks env for `" + consts.ENV_KEY_CA_CERT_PEM + "`.",
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to a PEM encoded CA certificate to trust in addition to the system CAs. Conflicts with `ca_cert_pem`. If neither is set, checks env for `" + consts.ENV_KEY_CA_CERT_FILE + "`.",
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Skip verification of the Coolify TLS certificate. Only use this for testing, and not together with a CA certificate. If not set, checks env for `" + consts.ENV_KEY_INSECURE_SKIP_VERIFY + "`. Default: `false`.",
+			},
+			"client_cert_pem": schema.StringAttribute{
+				Optional:    true,
+				Description: "PEM encoded client certificate for mutual TLS. Requires a client key. Conflicts with `client_cert_file`. If neither is set, checks env for `" + consts.ENV_KEY_CLIENT_CERT_PEM + "`.",
+			},
+			"client_cert_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to a PEM encoded client certificate for mutual TLS. Requires a client key. Conflicts with `client_cert_pem`. If neither is set, checks env for `" + consts.ENV_KEY_CLIENT_CERT_FILE + "`.",
+			},
+			"client_key_pem": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "PEM encoded private key of the client certificate. Conflicts with `client_key_file`. If neither is set, checks env for `" + consts.ENV_KEY_CLIENT_KEY_PEM + "`.",
+			},
+			"client_key_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to the PEM encoded private key of the client certificate. Conflicts with `client_key_pem`. If neither is set, checks env for `" + consts.ENV_KEY_CLIENT_KEY_FILE + "`.",
+			},
+			"proxy_url": schema.StringAttribute{
+				Optional:    true,
+				Description: "URL of the proxy to connect to Coolify through, e.g. `http://proxy.example.com:3128`. If not set, checks env for `" + consts.ENV_KEY_PROXY_URL + "`, then the standard `HTTPS_PROXY`, `HTTP_PROXY` and `NO_PROXY` variables.",
+			},
+			"headers": schema.MapAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				ElementType: types.StringType,
+				Description: "Extra headers to send with every request, e.g. for an authenticating proxy. The `Authorization`, `User-Agent` and `Accept` headers cannot be overridden. If not set, checks env for `" + consts.ENV_KEY_HEADERS + "` as comma separated `Name=value` pairs.",
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of requests sent to Coolify at once, regardless of Terraform's `-parallelism`, shared by the `token` and all `team_tokens`. If not set, checks env for `" + consts.ENV_KEY_MAX_CONCURRENT_REQUESTS + "`. Default: no limit.",
+			},
+			"cache_ttl": schema.Int64Attribute{
+				Optional: true,
+				Description: fmt.Sprintf("Seconds to reuse the responses of reads, such as listing projects or reading an application, so a plan or apply reading the same object many times sends one request. Identical concurrent reads are sent once. "+
+					"Starting, stopping, restarting or deploying a resource is never cached, and drops the cached reads of that resource. Set to `0` to disable caching. If not set, checks env for `%s`. Default: %d", consts.ENV_KEY_CACHE_TTL, consts.DEFAULT_CACHE_TTL),
+			},
+			"defaults": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "Where to place resources that do not set their own `server_uuid`, `project_uuid` or `environment_name`. The values are stored in the state of each resource when it is created, so changing them later does not move existing resources.",
+				Attributes: map[string]schema.Attribute{
+					"server_uuid": schema.StringAttribute{
+						Optional:    true,
+						Description: "UUID of the default server. If not set, checks env for `" + consts.ENV_KEY_DEFAULT_SERVER_UUID + "`.",
+					},
+					"project_uuid": schema.StringAttribute{
+						Optional:    true,
+						Description: "UUID of the default project. If not set, checks env for `" + consts.ENV_KEY_DEFAULT_PROJECT_UUID + "`.",
+					},
+					"environment_name": schema.StringAttribute{
+						Optional:    true,
+						Description: "Name of the default environment. If not set, checks env for `" + consts.ENV_KEY_DEFAULT_ENVIRONMENT_NAME + "`.",
+					},
+				},
+			},
+			"action_timeout": schema.StringAttribute{
+				Optional:    true,
+				Description: "How long actions wait for Coolify when they do not set their own `timeout`, as a duration such as `30s` or `5m`. If not set, checks env for `" + consts.ENV_KEY_ACTION_TIMEOUT + "`. Default: `10m`.",
+			},
 		},
 	}
 }
@@ -138,37 +285,54 @@ func (p *CoolifyProvider) Configure(ctx context.Context, req provider.ConfigureR
 		return
 	}
 
-	var apiEndpoint string
-	if !data.Endpoint.IsNull() {
-		apiEndpoint = data.Endpoint.ValueString()
-	} else if apiEndpointFromEnv, found := os.LookupEnv("COOLIFY_ENDPOINT"); found {
-		apiEndpoint = apiEndpointFromEnv
-	} else {
-		apiEndpoint = consts.DEFAULT_COOLIFY_ENDPOINT
-	}
+	apiEndpoint, apiToken := GetCredentials(&data, &resp.Diagnostics)
+	tokenCommand := GetTokenCommand(ctx, &data, &resp.Diagnostics)
+	tokenValidation := GetTokenValidation(&data, &resp.Diagnostics)
+	teamTokens := GetTeamTokens(ctx, &data, tokenValidation, &resp.Diagnostics)
 
 	if apiEndpoint == "" {
 		resp.Diagnostics.AddAttributeError(path.Root("endpoint"), "Failed to configure client", "No API Endpoint provided")
 	}
 
-	var apiToken string
-	if !data.Token.IsNull() {
-		apiToken = data.Token.ValueString()
-	} else {
-		if apiTokenFromEnv, found := os.LookupEnv(consts.ENV_KEY_TOKEN); found {
-			apiToken = apiTokenFromEnv
-		}
+	if apiToken == "" && tokenCommand == nil {
+		resp.Diagnostics.AddAttributeError(path.Root("token"), "Failed to configure client", "No token provided")
 	}
 
-	if apiToken == "" {
-		resp.Diagnostics.AddAttributeError(path.Root("token"), "Failed to configure client", "No token provided")
+	httpConfig := GetHTTPConfig(ctx, &data, &resp.Diagnostics)
+	defaults := GetDefaults(&data)
+	actionTimeout := GetActionTimeout(&data, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
+	tokens, tokenPath := api.StaticTokenSource(apiToken), path.Root("token")
+	if tokenCommand != nil {
+		commandTokens := api.NewCommandTokenSource(tokenCommand)
+		token, err := commandTokens.Token(ctx)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("token_command"), "Failed to obtain token", err.Error())
+			return
+		}
+		apiToken, tokens, tokenPath = token, commandTokens, path.Root("token_command")
+	}
+
+	ValidateToken(apiToken, tokenValidation, tokenPath, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	client, err := api.NewAPIClient(p.version, apiEndpoint, apiToken, GetRetryConfig(data.Retry))
+	// Shared by the clients of all teams, so they are limited and cached together
+	transport, err := api.NewTransport(apiEndpoint, GetRetryConfig(data.Retry), httpConfig)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to create API client",
+			err.Error(),
+		)
+		return
+	}
+
+	client, err := api.NewAPIClientWithTokenSource(p.version, apiEndpoint, tokens, transport)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to create API client",
@@ -186,6 +350,15 @@ func (p *CoolifyProvider) Configure(ctx context.Context, req provider.ConfigureR
 		return
 	}
 
+	if versionResp.StatusCode() == http.StatusUnauthorized {
+		detail := "Coolify rejected the token with 401 Unauthorized. Check that the token has not been revoked or expired, and that it was created on this Coolify instance."
+		if hint := api.TokenHint(apiToken); hint != "" {
+			detail += " " + hint
+		}
+		resp.Diagnostics.AddAttributeError(tokenPath, "Invalid Coolify token", detail)
+		return
+	}
+
 	if versionResp.StatusCode() != http.StatusOK {
 		resp.Diagnostics.AddError(
 			"Unexpected HTTP status code API client",
@@ -196,7 +369,7 @@ func (p *CoolifyProvider) Configure(ctx context.Context, req provider.ConfigureR
 
 	currentVersion := string(versionResp.Body)
 
-	if !isVersionCompatible(currentVersion, consts.MIN_COOLIFY_VERSION) {
+	if !capability.IsVersionCompatible(currentVersion, consts.MIN_COOLIFY_VERSION) {
 		resp.Diagnostics.AddError(
 			"Unsupported API version",
 			fmt.Sprintf("The Coolify API version %s is not supported. The minimum supported version is %s", currentVersion, consts.MIN_COOLIFY_VERSION),
@@ -206,8 +379,24 @@ func (p *CoolifyProvider) Configure(ctx context.Context, req provider.ConfigureR
 
 	tflog.Info(ctx, "Successfully connected to Coolify API", map[string]interface{}{"version": currentVersion})
 
-	resp.ResourceData = client
-	resp.DataSourceData = client
+	teamClients := VerifyTeamTokens(ctx, client, teamTokens, func(token string) (*api.ClientWithResponses, error) {
+		return api.NewAPIClientWithTokenSource(p.version, apiEndpoint, api.StaticTokenSource(token), transport)
+	}, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData := &util.ProviderData{
+		Client:        client,
+		Version:       currentVersion,
+		Defaults:      defaults,
+		ActionTimeout: actionTimeout,
+		TeamClients:   teamClients,
+	}
+	resp.ResourceData = providerData
+	resp.DataSourceData = providerData
+	resp.ListResourceData = providerData
+	resp.ActionData = providerData
 }
 
 func (p *CoolifyProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -216,7 +405,9 @@ func (p *CoolifyProvider) Resources(ctx context.Context) []func() resource.Resou
 		service.NewServerResource,
 		service.NewProjectResource,
 		service.NewApplicationEnvsResource,
+		service.NewApplicationEnvResource,
 		service.NewServiceEnvsResource,
+		service.NewServiceEnvResource,
 		service.NewPostgresqlDatabaseResource,
 		service.NewMySQLDatabaseResource,
 		service_ds.NewServiceResource,
@@ -241,8 +432,40 @@ func (p *CoolifyProvider) DataSources(ctx context.Context) []func() datasource.D
 	}
 }
 
+func (p *CoolifyProvider) ListResources(ctx context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		private_key.NewPrivateKeyListResource,
+		service.NewServerListResource,
+		service.NewProjectListResource,
+		service.NewPostgresqlDatabaseListResource,
+		service.NewMySQLDatabaseListResource,
+		service_ds.NewServiceListResource,
+	}
+}
+
+func (p *CoolifyProvider) Actions(ctx context.Context) []func() action.Action {
+	return []func() action.Action{
+		service.NewApplicationStartAction,
+		service.NewApplicationStopAction,
+		service.NewApplicationRestartAction,
+		service.NewDatabaseStartAction,
+		service.NewDatabaseStopAction,
+		service.NewDatabaseRestartAction,
+		service.NewServiceStartAction,
+		service.NewServiceStopAction,
+		service.NewServiceRestartAction,
+		service.NewDeployAction,
+	}
+}
+
 func (p *CoolifyProvider) Functions(ctx context.Context) []func() function.Function {
-	return []func() function.Function{}
+	return []func() function.Function{
+		service.NewParseDotenvFunction,
+		service.NewParseInternalDbUrlFunction,
+		service.NewBuildImportIdFunction,
+		service.NewValidateUuidFunction,
+		service.NewComposeServicesFunction,
+	}
 }
 
 func (p *CoolifyProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
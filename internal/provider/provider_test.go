@@ -2,8 +2,11 @@ package provider_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -50,14 +53,46 @@ func providerConfigDynamicValue(config map[string]interface{}) (tfprotov6.Dynami
 		"endpoint": tftypes.String,
 		"token":    tftypes.String,
 		"retry":    tftypes.Object{},
+
+		"config_file": tftypes.String,
+		"context":     tftypes.String,
+
+		"token_command":    tftypes.List{ElementType: tftypes.String},
+		"token_validation": tftypes.String,
+		"team_tokens":      tftypes.Map{ElementType: tftypes.String},
+
+		"request_timeout":      tftypes.Number,
+		"ca_cert_pem":          tftypes.String,
+		"ca_cert_file":         tftypes.String,
+		"insecure_skip_verify": tftypes.Bool,
+		"client_cert_pem":      tftypes.String,
+		"client_cert_file":     tftypes.String,
+		"client_key_pem":       tftypes.String,
+		"client_key_file":      tftypes.String,
+		"proxy_url":            tftypes.String,
+		"headers":              tftypes.Map{ElementType: tftypes.String},
+
+		"max_concurrent_requests": tftypes.Number,
+		"cache_ttl":               tftypes.Number,
+
+		"defaults": tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+			"server_uuid":      tftypes.String,
+			"project_uuid":     tftypes.String,
+			"environment_name": tftypes.String,
+		}},
+		"action_timeout": tftypes.String,
 	}
 	providerConfigObjectType := tftypes.Object{AttributeTypes: providerConfigTypes}
 
-	providerConfigObjectValue := tftypes.NewValue(providerConfigObjectType, map[string]tftypes.Value{
-		"endpoint": tftypes.NewValue(tftypes.String, config["endpoint"]),
-		"token":    tftypes.NewValue(tftypes.String, config["token"]),
-		"retry":    tftypes.NewValue(tftypes.Object{}, nil),
-	})
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range providerConfigTypes {
+		if value, ok := config[name]; ok && attributeType.Is(tftypes.String) {
+			values[name] = tftypes.NewValue(attributeType, value)
+		} else {
+			values[name] = tftypes.NewValue(attributeType, nil)
+		}
+	}
+	providerConfigObjectValue := tftypes.NewValue(providerConfigObjectType, values)
 
 	value, err := tfprotov6.NewDynamicValue(providerConfigObjectType, providerConfigObjectValue)
 	if err != nil {
@@ -88,6 +123,16 @@ func TestProtocol6ProviderServerConfigure(t *testing.T) {
 	accEndpoint := os.Getenv(consts.ENV_KEY_ENDPOINT)
 	accToken := os.Getenv(consts.ENV_KEY_TOKEN)
 
+	cliConfigFile := filepath.Join(t.TempDir(), "config.json")
+	cliConfig, err := json.Marshal(map[string]interface{}{
+		"instances": []map[string]interface{}{
+			{"name": "acc", "fqdn": strings.TrimSuffix(accEndpoint, "/api/v1"), "token": accToken, "default": true},
+			{"name": "other", "fqdn": "https://coolify.invalid", "token": "1|other"},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(cliConfigFile, cliConfig, 0o600))
+
 	tests := map[string]struct {
 		config          map[string]interface{}
 		env             map[string]string
@@ -126,6 +171,14 @@ func TestProtocol6ProviderServerConfigure(t *testing.T) {
 			},
 			expectedSuccess: false,
 		},
+		"config: endpoint,token(invalid),token_validation(warn)": {
+			config: map[string]interface{}{
+				"endpoint":         accEndpoint,
+				"token":            "invalid_token",
+				"token_validation": "warn",
+			},
+			expectedSuccess: false,
+		},
 		"env: endpoint": {
 			env: map[string]string{
 				consts.ENV_KEY_ENDPOINT: accEndpoint,
@@ -148,12 +201,54 @@ func TestProtocol6ProviderServerConfigure(t *testing.T) {
 			},
 			expectedSuccess: true,
 		},
+		"config: config_file": {
+			config: map[string]interface{}{
+				"config_file": cliConfigFile,
+			},
+			expectedSuccess: true,
+		},
+		"config: config_file,context": {
+			config: map[string]interface{}{
+				"config_file": cliConfigFile,
+				"context":     "acc",
+			},
+			expectedSuccess: true,
+		},
+		"config: config_file,context(unknown)": {
+			config: map[string]interface{}{
+				"config_file": cliConfigFile,
+				"context":     "missing",
+			},
+			expectedSuccess: false,
+		},
+		"config: config_file,context env: endpoint,token": {
+			config: map[string]interface{}{
+				"config_file": cliConfigFile,
+				"context":     "acc",
+			},
+			env: map[string]string{
+				consts.ENV_KEY_ENDPOINT: "https://coolify.invalid/api/v1",
+				consts.ENV_KEY_TOKEN:    "1|other",
+			},
+			expectedSuccess: true,
+		},
+		"config: config_file,endpoint env: context": {
+			config: map[string]interface{}{
+				"config_file": cliConfigFile,
+				"endpoint":    accEndpoint,
+			},
+			env: map[string]string{
+				consts.ENV_KEY_CONTEXT: "other",
+			},
+			expectedSuccess: false,
+		},
 	}
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
 			t.Setenv(consts.ENV_KEY_ENDPOINT, "")
 			t.Setenv(consts.ENV_KEY_TOKEN, "")
+			t.Setenv(consts.ENV_KEY_CONTEXT, "")
 			for key, value := range test.env {
 				t.Setenv(key, value)
 			}
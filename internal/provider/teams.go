@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-coolify/internal/api"
+)
+
+// GetTeamTokens reads the `team_tokens` argument keyed by team ID, and checks
+// the format of each token.
+func GetTeamTokens(ctx context.Context, data *CoolifyProviderModel, tokenValidation string, diags *diag.Diagnostics) map[int64]string {
+	if data.TeamTokens.IsNull() || data.TeamTokens.IsUnknown() {
+		return nil
+	}
+
+	var tokensByKey map[string]string
+	diags.Append(data.TeamTokens.ElementsAs(ctx, &tokensByKey, false)...)
+
+	tokens := make(map[int64]string, len(tokensByKey))
+	for key, token := range tokensByKey {
+		teamId, err := strconv.ParseInt(key, 10, 64)
+		if err != nil || teamId < 0 {
+			diags.AddAttributeError(
+				path.Root("team_tokens").AtMapKey(key),
+				"Invalid provider configuration",
+				fmt.Sprintf("`team_tokens` must be keyed by team ID, e.g. `\"3\"`, got: %q", key),
+			)
+			continue
+		}
+
+		ValidateToken(token, tokenValidation, path.Root("team_tokens").AtMapKey(key), diags)
+		tokens[teamId] = token
+	}
+
+	return tokens
+}
+
+// VerifyTeamTokens creates a client for each team token, and checks with
+// Coolify that the token belongs to the team it is set for, so resources are
+// never managed with the token of another team. The default client is also
+// registered for its own team, so resources can set that team explicitly.
+func VerifyTeamTokens(
+	ctx context.Context,
+	defaultClient *api.ClientWithResponses,
+	tokens map[int64]string,
+	newClient func(token string) (*api.ClientWithResponses, error),
+	diags *diag.Diagnostics,
+) map[int64]*api.ClientWithResponses {
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	teamIds := make([]int64, 0, len(tokens))
+	for teamId := range tokens {
+		teamIds = append(teamIds, teamId)
+	}
+	sort.Slice(teamIds, func(i, j int) bool { return teamIds[i] < teamIds[j] })
+
+	clients := make(map[int64]*api.ClientWithResponses, len(tokens)+1)
+	for _, teamId := range teamIds {
+		attribute := path.Root("team_tokens").AtMapKey(strconv.FormatInt(teamId, 10))
+
+		client, err := newClient(tokens[teamId])
+		if err != nil {
+			diags.AddAttributeError(attribute, "Failed to create API client", err.Error())
+			continue
+		}
+
+		currentTeamId, err := currentTeamId(ctx, client)
+		if err != nil {
+			diags.AddAttributeError(attribute, "Failed to verify team token", fmt.Sprintf("Unable to read the team of the token for team %d: %s", teamId, err))
+			continue
+		}
+		if currentTeamId != teamId {
+			diags.AddAttributeError(
+				attribute,
+				"Team token mismatch",
+				fmt.Sprintf("The token set for team %d belongs to team %d. Each token in `team_tokens` must be created in the team it is set for.", teamId, currentTeamId),
+			)
+			continue
+		}
+
+		clients[teamId] = client
+	}
+
+	if defaultTeamId, err := currentTeamId(ctx, defaultClient); err != nil {
+		tflog.Warn(ctx, "Unable to read the team of the provider token", map[string]interface{}{"error": err.Error()})
+	} else if _, ok := clients[defaultTeamId]; !ok {
+		clients[defaultTeamId] = defaultClient
+	}
+
+	return clients
+}
+
+// MARK: Helper Functions
+
+// currentTeamId returns the ID of the team a client's token belongs to.
+func currentTeamId(ctx context.Context, client *api.ClientWithResponses) (int64, error) {
+	resp, err := client.GetCurrentTeamWithResponse(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if resp.StatusCode() == http.StatusUnauthorized {
+		return 0, errors.New("the token was rejected with 401 Unauthorized")
+	}
+	if resp.StatusCode() != http.StatusOK || resp.JSON200 == nil || resp.JSON200.Id == nil {
+		return 0, fmt.Errorf("received %s. Details: %s", resp.Status(), resp.Body)
+	}
+
+	return int64(*resp.JSON200.Id), nil
+}
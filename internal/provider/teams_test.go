@@ -0,0 +1,122 @@
+package provider_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"terraform-provider-coolify/internal/api"
+	"terraform-provider-coolify/internal/consts"
+	"terraform-provider-coolify/internal/provider"
+)
+
+func TestGetTeamTokens(t *testing.T) {
+	testCases := map[string]struct {
+		input         map[string]attr.Value
+		expected      map[int64]string
+		expectedError bool
+	}{
+		"not set": {},
+		"keyed by team id": {
+			input:    map[string]attr.Value{"0": types.StringValue("1|root"), "3": types.StringValue("2|other")},
+			expected: map[int64]string{0: "1|root", 3: "2|other"},
+		},
+		"invalid key": {
+			input:         map[string]attr.Value{"platform": types.StringValue("1|root")},
+			expected:      map[int64]string{},
+			expectedError: true,
+		},
+		"invalid token": {
+			input:         map[string]attr.Value{"3": types.StringValue("Bearer 2|other")},
+			expected:      map[int64]string{3: "Bearer 2|other"},
+			expectedError: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			data := provider.CoolifyProviderModel{TeamTokens: types.MapNull(types.StringType)}
+			if tc.input != nil {
+				data.TeamTokens = types.MapValueMust(types.StringType, tc.input)
+			}
+
+			var diags diag.Diagnostics
+			result := provider.GetTeamTokens(context.Background(), &data, consts.TOKEN_VALIDATION_STRICT, &diags)
+
+			assert.Equal(t, tc.expectedError, diags.HasError(), diags)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestVerifyTeamTokens(t *testing.T) {
+	teams := map[string]string{
+		"Bearer 1|default": `{"id": 0, "name": "Root Team"}`,
+		"Bearer 2|team":    `{"id": 3, "name": "Platform"}`,
+		"Bearer 3|team":    `{"id": 4, "name": "Data"}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		team, ok := teams[r.Header.Get("Authorization")]
+		if r.URL.Path != "/teams/current" || !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(team))
+	}))
+	defer server.Close()
+
+	newClient := func(token string) (*api.ClientWithResponses, error) {
+		return api.NewAPIClient("test", server.URL, token, api.RetryConfig{MaxAttempts: 1, MinWait: 1, MaxWait: 1}, api.HTTPConfig{})
+	}
+	defaultClient, err := newClient("1|default")
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		tokens        map[int64]string
+		expectedTeams []int64
+		expectedError bool
+	}{
+		"not set": {},
+		"matching tokens": {
+			tokens:        map[int64]string{3: "2|team", 4: "3|team"},
+			expectedTeams: []int64{0, 3, 4},
+		},
+		"token of another team": {
+			tokens:        map[int64]string{3: "3|team"},
+			expectedError: true,
+		},
+		"rejected token": {
+			tokens:        map[int64]string{3: "9|revoked"},
+			expectedError: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			result := provider.VerifyTeamTokens(context.Background(), defaultClient, tc.tokens, newClient, &diags)
+
+			assert.Equal(t, tc.expectedError, diags.HasError(), diags)
+			if tc.expectedError {
+				return
+			}
+
+			var teamIds []int64
+			for teamId := range result {
+				teamIds = append(teamIds, teamId)
+			}
+			assert.ElementsMatch(t, tc.expectedTeams, teamIds)
+			if tc.expectedTeams != nil {
+				assert.Same(t, defaultClient, result[0])
+			}
+		})
+	}
+}
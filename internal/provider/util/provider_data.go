@@ -1,38 +1,134 @@
 package util
 
 import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-coolify/internal/api"
 )
 
-func ProviderDataFromDataSourceConfigureRequest[ProviderData interface{}](req datasource.ConfigureRequest, out *ProviderData, resp *datasource.ConfigureResponse) bool {
-	if req.ProviderData == nil {
-		return false
+// ProviderData is passed by the provider to resources, data sources, list
+// resources and actions once configured.
+type ProviderData struct {
+	Client *api.ClientWithResponses
+	// Version is the Coolify version detected when configuring the provider,
+	// used to check capabilities.
+	Version string
+
+	Defaults Defaults
+	// ActionTimeout is how long actions wait for Coolify when they do not set
+	// their own timeout, zero uses the action default.
+	ActionTimeout time.Duration
+
+	// TeamClients are authenticated with the `team_tokens` of the provider,
+	// keyed by the team each token was verified to belong to.
+	TeamClients map[int64]*api.ClientWithResponses
+}
+
+// ClientForTeam returns the client for the team owning a resource, or the
+// default client when the resource does not set a team.
+func (d *ProviderData) ClientForTeam(teamId types.Int64, diags *diag.Diagnostics) *api.ClientWithResponses {
+	if teamId.IsNull() || teamId.IsUnknown() {
+		return d.Client
 	}
 
-	if providerData, ok := req.ProviderData.(ProviderData); ok {
-		*out = providerData
+	client, ok := d.TeamClients[teamId.ValueInt64()]
+	if !ok {
+		diags.AddAttributeError(
+			path.Root("team_id"),
+			"Missing team token",
+			fmt.Sprintf("No token is configured for team %d. Add one to the provider `team_tokens`.", teamId.ValueInt64()),
+		)
+		return nil
+	}
 
-		return true
+	return client
+}
+
+// CheckPlannedTeam reports a planned `team_id` without a token, so it fails
+// before anything is applied. Nothing is checked when destroying.
+func (d *ProviderData) CheckPlannedTeam(ctx context.Context, plan tfsdk.Plan, diags *diag.Diagnostics) {
+	if plan.Raw.IsNull() {
+		return
 	}
 
-	resp.Diagnostics.AddError("Invalid provider data", "")
+	var teamId types.Int64
+	diags.Append(plan.GetAttribute(ctx, path.Root("team_id"), &teamId)...)
+	d.ClientForTeam(teamId, diags)
+}
 
-	return false
+// ClientForOwnerTeam returns the client for a team read from the API rather
+// than configured. Without `team_tokens`, every resource the provider can
+// read belongs to the team of the provider token, so the default client is
+// returned.
+func (d *ProviderData) ClientForOwnerTeam(teamId types.Int64, diags *diag.Diagnostics) *api.ClientWithResponses {
+	if len(d.TeamClients) == 0 {
+		return d.Client
+	}
+
+	return d.ClientForTeam(teamId, diags)
 }
 
-func ProviderDataFromResourceConfigureRequest[ProviderData interface{}](req resource.ConfigureRequest, out *ProviderData, resp *resource.ConfigureResponse) bool {
-	if req.ProviderData == nil {
+// Defaults place resources that do not set their own server, project or
+// environment.
+type Defaults struct {
+	ServerUuid      string
+	ProjectUuid     string
+	EnvironmentName string
+}
+
+// Placement returns the defaults keyed by the attribute they apply to.
+func (d Defaults) Placement() map[string]string {
+	return map[string]string{
+		"server_uuid":      d.ServerUuid,
+		"project_uuid":     d.ProjectUuid,
+		"environment_name": d.EnvironmentName,
+	}
+}
+
+// providerDataFrom extracts the provider data, or just its client for the
+// many implementations that need nothing else.
+func providerDataFrom[T interface{}](data any, out *T, diags *diag.Diagnostics) bool {
+	if data == nil {
 		return false
 	}
 
-	if providerData, ok := req.ProviderData.(ProviderData); ok {
+	if providerData, ok := data.(T); ok {
 		*out = providerData
 
 		return true
 	}
 
-	resp.Diagnostics.AddError("Invalid provider data", "")
+	if providerData, ok := data.(*ProviderData); ok {
+		if client, ok := any(providerData.Client).(T); ok {
+			*out = client
+
+			return true
+		}
+	}
+
+	diags.AddError("Invalid provider data", "")
 
 	return false
 }
+
+func ProviderDataFromDataSourceConfigureRequest[ProviderData interface{}](req datasource.ConfigureRequest, out *ProviderData, resp *datasource.ConfigureResponse) bool {
+	return providerDataFrom(req.ProviderData, out, &resp.Diagnostics)
+}
+
+func ProviderDataFromResourceConfigureRequest[ProviderData interface{}](req resource.ConfigureRequest, out *ProviderData, resp *resource.ConfigureResponse) bool {
+	return providerDataFrom(req.ProviderData, out, &resp.Diagnostics)
+}
+
+func ProviderDataFromActionConfigureRequest[ProviderData interface{}](req action.ConfigureRequest, out *ProviderData, resp *action.ConfigureResponse) bool {
+	return providerDataFrom(req.ProviderData, out, &resp.Diagnostics)
+}
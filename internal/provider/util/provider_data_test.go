@@ -3,9 +3,13 @@ package util
 import (
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-coolify/internal/api"
 )
 
 type mockProviderData struct {
@@ -95,3 +99,151 @@ func TestProviderDataFromResourceConfigureRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestProviderDataFromActionConfigureRequest(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name          string
+		providerData  any
+		expected      bool
+		expectError   bool
+		expectedValue string
+	}{
+		{"NilProviderData", nil, false, false, ""},
+		{"ValidProviderData", mockProviderData{Value: "test"}, true, false, "test"},
+		{"InvalidProviderData", "invalid", false, true, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			req := action.ConfigureRequest{ProviderData: tt.providerData}
+			resp := &action.ConfigureResponse{Diagnostics: diag.Diagnostics{}}
+			var out mockProviderData
+
+			got := ProviderDataFromActionConfigureRequest(req, &out, resp)
+
+			if got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+
+			if tt.expectError && len(resp.Diagnostics) == 0 {
+				t.Error("expected error diagnostics, got none")
+			}
+
+			if !tt.expectError && len(resp.Diagnostics) > 0 {
+				t.Error("expected no error diagnostics, got some")
+			}
+
+			if tt.expected && out.Value != tt.expectedValue {
+				t.Errorf("expected value %s, got %s", tt.expectedValue, out.Value)
+			}
+		})
+	}
+}
+
+func TestProviderDataClient(t *testing.T) {
+	t.Parallel()
+	client := &api.ClientWithResponses{}
+	providerData := &ProviderData{Client: client, Version: "4.0.0"}
+
+	t.Run("Client", func(t *testing.T) {
+		t.Parallel()
+		req := resource.ConfigureRequest{ProviderData: providerData}
+		resp := &resource.ConfigureResponse{Diagnostics: diag.Diagnostics{}}
+		var out *api.ClientWithResponses
+
+		if !ProviderDataFromResourceConfigureRequest(req, &out, resp) {
+			t.Fatalf("expected client to be extracted, got diagnostics %v", resp.Diagnostics)
+		}
+		if out != client {
+			t.Errorf("expected client %p, got %p", client, out)
+		}
+	})
+
+	t.Run("ProviderData", func(t *testing.T) {
+		t.Parallel()
+		req := resource.ConfigureRequest{ProviderData: providerData}
+		resp := &resource.ConfigureResponse{Diagnostics: diag.Diagnostics{}}
+		var out *ProviderData
+
+		if !ProviderDataFromResourceConfigureRequest(req, &out, resp) {
+			t.Fatalf("expected provider data to be extracted, got diagnostics %v", resp.Diagnostics)
+		}
+		if out.Version != "4.0.0" {
+			t.Errorf("expected version 4.0.0, got %s", out.Version)
+		}
+	})
+}
+
+func TestProviderDataClientForTeam(t *testing.T) {
+	t.Parallel()
+	client, teamClient := &api.ClientWithResponses{}, &api.ClientWithResponses{}
+	providerData := &ProviderData{
+		Client:      client,
+		TeamClients: map[int64]*api.ClientWithResponses{3: teamClient},
+	}
+
+	tests := []struct {
+		name        string
+		teamId      types.Int64
+		expected    *api.ClientWithResponses
+		expectError bool
+	}{
+		{"NoTeam", types.Int64Null(), client, false},
+		{"UnknownTeam", types.Int64Unknown(), client, false},
+		{"TeamWithToken", types.Int64Value(3), teamClient, false},
+		{"TeamWithoutToken", types.Int64Value(4), nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var diags diag.Diagnostics
+
+			got := providerData.ClientForTeam(tt.teamId, &diags)
+
+			if got != tt.expected {
+				t.Errorf("expected client %p, got %p", tt.expected, got)
+			}
+			if tt.expectError != diags.HasError() {
+				t.Errorf("expected error %v, got diagnostics %v", tt.expectError, diags)
+			}
+		})
+	}
+}
+
+func TestProviderDataClientForOwnerTeam(t *testing.T) {
+	t.Parallel()
+	client, teamClient := &api.ClientWithResponses{}, &api.ClientWithResponses{}
+
+	tests := []struct {
+		name        string
+		teamClients map[int64]*api.ClientWithResponses
+		teamId      types.Int64
+		expected    *api.ClientWithResponses
+		expectError bool
+	}{
+		{"NoTeamTokens", nil, types.Int64Value(0), client, false},
+		{"TeamWithToken", map[int64]*api.ClientWithResponses{0: client, 3: teamClient}, types.Int64Value(3), teamClient, false},
+		{"TeamOfProviderToken", map[int64]*api.ClientWithResponses{0: client, 3: teamClient}, types.Int64Value(0), client, false},
+		{"TeamWithoutToken", map[int64]*api.ClientWithResponses{0: client, 3: teamClient}, types.Int64Value(4), nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var diags diag.Diagnostics
+			providerData := &ProviderData{Client: client, TeamClients: tt.teamClients}
+
+			got := providerData.ClientForOwnerTeam(tt.teamId, &diags)
+
+			if got != tt.expected {
+				t.Errorf("expected client %p, got %p", tt.expected, got)
+			}
+			if tt.expectError != diags.HasError() {
+				t.Errorf("expected error %v, got diagnostics %v", tt.expectError, diags)
+			}
+		})
+	}
+}
@@ -0,0 +1,291 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-coolify/internal/api"
+	"terraform-provider-coolify/internal/provider/util"
+)
+
+const (
+	// actionPollInterval is how often actions poll the API while waiting for
+	// a resource to reach its target state.
+	actionPollInterval   = 5 * time.Second
+	defaultActionTimeout = 10 * time.Minute
+	// restartObserveWindow is how long a restart waits for the resource to
+	// leave its running status, before assuming the restart completed
+	// between two reads.
+	restartObserveWindow = time.Minute
+)
+
+// Deployment statuses reported by Coolify.
+const (
+	deploymentStatusFinished  = "finished"
+	deploymentStatusFailed    = "failed"
+	deploymentStatusCancelled = "cancelled-by-user"
+)
+
+// errStatusNotReported is returned when the API does not report a status for
+// a resource, so there is nothing to wait for.
+var errStatusNotReported = errors.New("status not reported by the API")
+
+func actionTimeoutAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:    true,
+		Description: "How long to wait for the action to complete, as a duration such as `30s` or `5m`. Defaults to the provider `action_timeout`, or `10m`.",
+	}
+}
+
+func actionTeamIdAttribute() schema.Int64Attribute {
+	return schema.Int64Attribute{
+		Optional:    true,
+		Description: "ID of the team owning the resources, acted on with the token set for it in the provider `team_tokens`. Defaults to the team of the provider token.",
+	}
+}
+
+// actionClient returns the client for the `team_id` attribute of an action
+// config.
+func actionClient(ctx context.Context, config tfsdk.Config, providerData *util.ProviderData, diags *diag.Diagnostics) *api.ClientWithResponses {
+	var teamId types.Int64
+	diags.Append(config.GetAttribute(ctx, path.Root("team_id"), &teamId)...)
+	if diags.HasError() {
+		return nil
+	}
+
+	return providerData.ClientForTeam(teamId, diags)
+}
+
+// actionTimeout reads the `timeout` attribute of an action config, falling
+// back to the provider timeout when set.
+func actionTimeout(ctx context.Context, config tfsdk.Config, providerTimeout time.Duration, diags *diag.Diagnostics) (time.Duration, bool) {
+	var timeout types.String
+	diags.Append(config.GetAttribute(ctx, path.Root("timeout"), &timeout)...)
+	if diags.HasError() {
+		return 0, false
+	}
+
+	if timeout.IsNull() || timeout.IsUnknown() {
+		if providerTimeout > 0 {
+			return providerTimeout, true
+		}
+		return defaultActionTimeout, true
+	}
+
+	duration, err := time.ParseDuration(timeout.ValueString())
+	if err != nil || duration <= 0 {
+		diags.AddAttributeError(
+			path.Root("timeout"),
+			"Invalid timeout",
+			fmt.Sprintf("Expected a positive duration such as \"30s\" or \"5m\", got: %q", timeout.ValueString()),
+		)
+		return 0, false
+	}
+
+	return duration, true
+}
+
+// sendProgress reports progress to Terraform, if the invocation supports it.
+func sendProgress(resp *action.InvokeResponse, format string, args ...any) {
+	if resp.SendProgress != nil {
+		resp.SendProgress(action.InvokeProgressEvent{Message: fmt.Sprintf(format, args...)})
+	}
+}
+
+// pollUntil calls check every interval until it reports done, returns an
+// error or the context is done.
+func pollUntil(ctx context.Context, interval time.Duration, check func() (bool, error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		done, err := check()
+		if err != nil || done {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// readResourceStatus reads the status of an application, database or service,
+// e.g. "running:healthy" or "exited". Not every resource type documents the
+// status in the API spec, so it is decoded from the raw response body.
+func readResourceStatus(ctx context.Context, client *api.ClientWithResponses, resourceType, uuid string) (string, error) {
+	var statusCode int
+	var status string
+	var body []byte
+	var err error
+
+	switch resourceType {
+	case "application":
+		var resp *api.GetApplicationByUuidResponse
+		if resp, err = client.GetApplicationByUuidWithResponse(ctx, uuid); err == nil {
+			statusCode, status, body = resp.StatusCode(), resp.Status(), resp.Body
+		}
+	case "database":
+		var resp *api.GetDatabaseByUuidResponse
+		if resp, err = client.GetDatabaseByUuidWithResponse(ctx, uuid); err == nil {
+			statusCode, status, body = resp.StatusCode(), resp.Status(), resp.Body
+		}
+	case "service":
+		var resp *api.GetServiceByUuidResponse
+		if resp, err = client.GetServiceByUuidWithResponse(ctx, uuid); err == nil {
+			statusCode, status, body = resp.StatusCode(), resp.Status(), resp.Body
+		}
+	default:
+		return "", fmt.Errorf("unsupported resource type: %s", resourceType)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if statusCode != http.StatusOK {
+		return "", fmt.Errorf("received %s for %s: uuid=%s. Details: %s", status, resourceType, uuid, body)
+	}
+
+	var resource struct {
+		Status *string `json:"status"`
+	}
+	if err := json.Unmarshal(body, &resource); err != nil {
+		return "", fmt.Errorf("decoding %s: uuid=%s: %w", resourceType, uuid, err)
+	}
+	if resource.Status == nil || *resource.Status == "" {
+		return "", errStatusNotReported
+	}
+
+	return *resource.Status, nil
+}
+
+// waitForResourceStatus waits until the status of a resource starts with the
+// target prefix, e.g. "running" or "exited". The last status read is returned
+// alongside any error.
+func waitForResourceStatus(
+	ctx context.Context,
+	client *api.ClientWithResponses,
+	interval time.Duration,
+	resourceType, uuid, target string,
+	progress func(status string),
+) (string, error) {
+	var last string
+	err := pollUntil(ctx, interval, func() (bool, error) {
+		status, err := readResourceStatus(ctx, client, resourceType, uuid)
+		if err != nil {
+			return false, err
+		}
+		if status != last {
+			last = status
+			progress(status)
+		}
+		return strings.HasPrefix(status, target), nil
+	})
+
+	return last, err
+}
+
+// waitForResourceRestart waits until the status of a restarted resource has
+// left the target prefix and reached it again, as the resource keeps
+// reporting its old status until Coolify acts on the restart. If the status
+// does not leave the target within observeWindow, the restart is assumed to
+// have completed between two reads, and false is returned. The last status
+// read is returned alongside any error.
+func waitForResourceRestart(
+	ctx context.Context,
+	client *api.ClientWithResponses,
+	interval, observeWindow time.Duration,
+	resourceType, uuid, target string,
+	progress func(status string),
+) (string, bool, error) {
+	var last string
+	left := false
+	observeDeadline := time.Now().Add(observeWindow)
+	err := pollUntil(ctx, interval, func() (bool, error) {
+		status, err := readResourceStatus(ctx, client, resourceType, uuid)
+		if err != nil {
+			return false, err
+		}
+		if status != last {
+			last = status
+			progress(status)
+		}
+		if !strings.HasPrefix(status, target) {
+			left = true
+			return false, nil
+		}
+		return left || time.Now().After(observeDeadline), nil
+	})
+
+	return last, left, err
+}
+
+// waitForDeployment waits until a deployment has finished. Failed and
+// cancelled deployments are returned as errors. The last status read is
+// returned alongside any error.
+func waitForDeployment(
+	ctx context.Context,
+	client *api.ClientWithResponses,
+	interval time.Duration,
+	deploymentUuid string,
+	progress func(status string),
+) (string, error) {
+	var last string
+	err := pollUntil(ctx, interval, func() (bool, error) {
+		resp, err := client.GetDeploymentByUuidWithResponse(ctx, deploymentUuid)
+		if err != nil {
+			return false, err
+		}
+
+		if resp.StatusCode() != http.StatusOK {
+			return false, fmt.Errorf("received %s for deployment: uuid=%s. Details: %s", resp.Status(), deploymentUuid, resp.Body)
+		}
+
+		var status string
+		if resp.JSON200.Status != nil {
+			status = *resp.JSON200.Status
+		}
+		if status != last {
+			last = status
+			progress(status)
+		}
+
+		switch status {
+		case deploymentStatusFinished:
+			return true, nil
+		case deploymentStatusFailed, deploymentStatusCancelled:
+			return false, fmt.Errorf("deployment %s %s", deploymentUuid, status)
+		}
+		return false, nil
+	})
+
+	return last, err
+}
+
+// addWaitError adds a diagnostic for an error returned while waiting, telling
+// timeouts apart from API errors.
+func addWaitError(ctx context.Context, diags *diag.Diagnostics, subject string, lastStatus string, err error) {
+	if ctx.Err() != nil {
+		diags.AddError(
+			fmt.Sprintf("Timed out waiting for %s", subject),
+			fmt.Sprintf("Last reported status: %q. Increase `timeout` if the resource is slow to change state.", lastStatus),
+		)
+		return
+	}
+
+	diags.AddError(fmt.Sprintf("Error waiting for %s", subject), err.Error())
+}
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"terraform-provider-coolify/internal/api"
+	"terraform-provider-coolify/internal/provider/util"
+)
+
+// sequenceServer serves the responses for each path in order, repeating the
+// last one once exhausted.
+func sequenceServer(t *testing.T, responses map[string][]string) *api.ClientWithResponses {
+	var mu sync.Mutex
+	served := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		key := r.Method + " " + r.URL.Path
+		bodies, ok := responses[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		i := min(served[key], len(bodies)-1)
+		served[key]++
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(bodies[i]))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := api.NewAPIClient("test", server.URL, "1|token", api.RetryConfig{MaxAttempts: 1, MinWait: 1, MaxWait: 1}, api.HTTPConfig{})
+	require.NoError(t, err)
+	return client
+}
+
+func TestWaitForResourceStatus(t *testing.T) {
+	client := sequenceServer(t, map[string][]string{
+		"GET /databases/db1": {`{"status": "restarting"}`, `{"status": "running:unknown"}`, `{"status": "running:healthy"}`},
+		"GET /services/svc1": {`{"uuid": "svc1"}`},
+	})
+
+	var seen []string
+	status, err := waitForResourceStatus(context.Background(), client, time.Millisecond, "database", "db1", "running", func(status string) {
+		seen = append(seen, status)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "running:unknown", status)
+	assert.Equal(t, []string{"restarting", "running:unknown"}, seen)
+
+	_, err = waitForResourceStatus(context.Background(), client, time.Millisecond, "service", "svc1", "running", func(string) {})
+	assert.ErrorIs(t, err, errStatusNotReported)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	status, err = waitForResourceStatus(ctx, client, time.Millisecond, "database", "db1", "exited", func(string) {})
+	assert.Error(t, err)
+	assert.Equal(t, "running:healthy", status)
+}
+
+func TestWaitForResourceRestart(t *testing.T) {
+	client := sequenceServer(t, map[string][]string{
+		"GET /databases/db1": {`{"status": "running:healthy"}`, `{"status": "restarting"}`, `{"status": "running:unknown"}`},
+		"GET /databases/db2": {`{"status": "running:healthy"}`},
+	})
+
+	var seen []string
+	status, observed, err := waitForResourceRestart(context.Background(), client, time.Millisecond, time.Minute, "database", "db1", "running", func(status string) {
+		seen = append(seen, status)
+	})
+	require.NoError(t, err)
+	assert.True(t, observed)
+	assert.Equal(t, "running:unknown", status)
+	assert.Equal(t, []string{"running:healthy", "restarting", "running:unknown"}, seen)
+
+	status, observed, err = waitForResourceRestart(context.Background(), client, time.Millisecond, 10*time.Millisecond, "database", "db2", "running", func(string) {})
+	require.NoError(t, err)
+	assert.False(t, observed)
+	assert.Equal(t, "running:healthy", status)
+}
+
+func TestWaitForDeployment(t *testing.T) {
+	client := sequenceServer(t, map[string][]string{
+		"GET /deployments/ok":     {`{"status": "queued"}`, `{"status": "in_progress"}`, `{"status": "finished"}`},
+		"GET /deployments/failed": {`{"status": "in_progress"}`, `{"status": "failed"}`},
+	})
+
+	var seen []string
+	status, err := waitForDeployment(context.Background(), client, time.Millisecond, "ok", func(status string) {
+		seen = append(seen, status)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "finished", status)
+	assert.Equal(t, []string{"queued", "in_progress", "finished"}, seen)
+
+	status, err = waitForDeployment(context.Background(), client, time.Millisecond, "failed", func(string) {})
+	assert.EqualError(t, err, "deployment failed failed")
+	assert.Equal(t, "failed", status)
+}
+
+func TestLifecycleActionInvoke(t *testing.T) {
+	ctx := context.Background()
+	client := sequenceServer(t, map[string][]string{
+		"GET /applications/app1/restart": {`{"message": "Restart request queued.", "deployment_uuid": "dep1"}`},
+		"GET /deployments/dep1":          {`{"status": "in_progress"}`, `{"status": "finished"}`},
+		"GET /applications/app1":         {`{"status": "running:healthy"}`},
+	})
+
+	a := newLifecycleAction("application", lifecycleRestart)
+	a.providerData = &util.ProviderData{Client: client}
+	a.pollInterval = time.Millisecond
+
+	schemaResp := &action.SchemaResponse{}
+	a.Schema(ctx, action.SchemaRequest{}, schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	config := tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+			"uuid":    tftypes.NewValue(tftypes.String, "app1"),
+			"team_id": tftypes.NewValue(tftypes.Number, nil),
+			"timeout": tftypes.NewValue(tftypes.String, "1m"),
+		}),
+	}
+
+	var progress []string
+	resp := &action.InvokeResponse{
+		SendProgress: func(event action.InvokeProgressEvent) {
+			progress = append(progress, event.Message)
+		},
+	}
+	a.Invoke(ctx, action.InvokeRequest{Config: config}, resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	assert.Equal(t, []string{
+		"Restart request queued.",
+		"Deployment dep1 is in_progress",
+		"Deployment dep1 is finished",
+		"application app1 is running:healthy",
+	}, progress)
+}
+
+func TestLifecycleActionInvokeTeam(t *testing.T) {
+	ctx := context.Background()
+	teamClient := sequenceServer(t, map[string][]string{
+		"GET /databases/db1/restart": {`{"message": "Restart request queued."}`},
+		"GET /databases/db1":         {`{"status": "running:healthy"}`, `{"status": "restarting"}`, `{"status": "running:healthy"}`},
+	})
+
+	a := newLifecycleAction("database", lifecycleRestart)
+	a.providerData = &util.ProviderData{
+		Client:      sequenceServer(t, map[string][]string{}),
+		TeamClients: map[int64]*api.ClientWithResponses{2: teamClient},
+	}
+	a.pollInterval = time.Millisecond
+
+	schemaResp := &action.SchemaResponse{}
+	a.Schema(ctx, action.SchemaRequest{}, schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	config := func(teamId int64) tfsdk.Config {
+		return tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+				"uuid":    tftypes.NewValue(tftypes.String, "db1"),
+				"team_id": tftypes.NewValue(tftypes.Number, teamId),
+				"timeout": tftypes.NewValue(tftypes.String, "1m"),
+			}),
+		}
+	}
+
+	var progress []string
+	resp := &action.InvokeResponse{
+		SendProgress: func(event action.InvokeProgressEvent) {
+			progress = append(progress, event.Message)
+		},
+	}
+	a.Invoke(ctx, action.InvokeRequest{Config: config(2)}, resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	assert.Empty(t, resp.Diagnostics)
+
+	assert.Equal(t, []string{
+		"Restart request queued.",
+		"database db1 is running:healthy",
+		"database db1 is restarting",
+		"database db1 is running:healthy",
+	}, progress)
+
+	resp = &action.InvokeResponse{}
+	a.Invoke(ctx, action.InvokeRequest{Config: config(3)}, resp)
+	assert.True(t, resp.Diagnostics.HasError())
+}
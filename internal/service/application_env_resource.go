@@ -0,0 +1,461 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-coolify/internal/api"
+	"terraform-provider-coolify/internal/flatten"
+	"terraform-provider-coolify/internal/provider/generated/resource_application_envs"
+	"terraform-provider-coolify/internal/provider/util"
+	sutil "terraform-provider-coolify/internal/service/util"
+)
+
+var (
+	_ resource.Resource                = &applicationEnvResource{}
+	_ resource.ResourceWithConfigure   = &applicationEnvResource{}
+	_ resource.ResourceWithImportState = &applicationEnvResource{}
+	_ resource.ResourceWithIdentity    = &applicationEnvResource{}
+	_ resource.ResourceWithModifyPlan  = &applicationEnvResource{}
+)
+
+func NewApplicationEnvResource() resource.Resource {
+	return &applicationEnvResource{}
+}
+
+type applicationEnvResource struct {
+	providerData *util.ProviderData
+}
+
+type applicationEnvResourceModel struct {
+	resource_application_envs.ApplicationEnvsModel
+	ApplicationUuid types.String `tfsdk:"application_uuid"`
+
+	ValueWo        types.String `tfsdk:"value_wo"`
+	ValueWoVersion types.Int64  `tfsdk:"value_wo_version"`
+	TeamId         types.Int64  `tfsdk:"team_id"`
+}
+
+type applicationEnvIdentityModel struct {
+	ApplicationUuid types.String `tfsdk:"application_uuid"`
+	Key             types.String `tfsdk:"key"`
+	IsPreview       types.Bool   `tfsdk:"is_preview"`
+	TeamId          types.Int64  `tfsdk:"team_id"`
+}
+
+func (m applicationEnvResourceModel) Identity() applicationEnvIdentityModel {
+	return applicationEnvIdentityModel{
+		ApplicationUuid: m.ApplicationUuid,
+		Key:             m.Key,
+		IsPreview:       m.IsPreview,
+		TeamId:          m.TeamId,
+	}
+}
+
+func (r *applicationEnvResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_application_env"
+}
+
+func (r *applicationEnvResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	codegenSchema := resource_application_envs.ApplicationEnvsResourceSchema(ctx)
+
+	resp.Schema = schema.Schema{
+		Description: "Create, read, update, and delete a single Application environment variable.",
+		MarkdownDescription: "Create, read, update, and delete a single Application environment variable.\n\n" +
+			"Unlike `coolify_application_envs`, each variable is managed on its own, so several modules can safely contribute variables to the same application. " +
+			"A variable is identified by its `application_uuid`, `key` and `is_preview`.",
+		Attributes: envFlagAttributes(codegenSchema.Attributes),
+	}
+
+	resp.Schema.Attributes["application_uuid"] = schema.StringAttribute{
+		Required:      true,
+		Description:   "UUID of the application.",
+		PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+	}
+	resp.Schema.Attributes["uuid"] = schema.StringAttribute{
+		Computed:      true,
+		Description:   "UUID of the environment variable.",
+		PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+	}
+	resp.Schema.Attributes["key"] = schema.StringAttribute{
+		Required:      true,
+		Description:   "The key of the environment variable.",
+		PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+	}
+	resp.Schema.Attributes["is_preview"] = schema.BoolAttribute{
+		Optional:      true,
+		Computed:      true,
+		Description:   "The flag to indicate if the environment variable is used in preview deployments.",
+		Default:       booldefault.StaticBool(false),
+		PlanModifiers: []planmodifier.Bool{boolplanmodifier.RequiresReplace()},
+	}
+
+	resp.Schema.Attributes["team_id"] = sutil.TeamIdAttribute()
+
+	for name, attribute := range writeOnlyAttributes("value", "The value of the environment variable", "Change this value to update the environment variable.") {
+		resp.Schema.Attributes[name] = attribute
+	}
+}
+
+func (r *applicationEnvResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	util.ProviderDataFromResourceConfigureRequest(req, &r.providerData, resp)
+}
+
+func (r *applicationEnvResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan applicationEnvResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	uuid := plan.ApplicationUuid.ValueString()
+	tflog.Debug(ctx, "Creating application env", map[string]interface{}{
+		"uuid": uuid,
+		"key":  plan.Key.ValueString(),
+	})
+
+	client := r.providerData.ClientForTeam(plan.TeamId, &resp.Diagnostics)
+	value := writeOnlyValueForAPI(ctx, req.Config, &resp.Diagnostics, "value", plan.Value, plan.ValueWoVersion, types.Int64Null())
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createResp, err := client.CreateEnvByApplicationUuidWithResponse(ctx, uuid, api.CreateEnvByApplicationUuidJSONRequestBody{
+		IsBuildTime: plan.IsBuildTime.ValueBoolPointer(),
+		IsLiteral:   plan.IsLiteral.ValueBoolPointer(),
+		IsMultiline: plan.IsMultiline.ValueBoolPointer(),
+		IsPreview:   plan.IsPreview.ValueBoolPointer(),
+		IsShownOnce: plan.IsShownOnce.ValueBoolPointer(),
+		Key:         plan.Key.ValueStringPointer(),
+		Value:       value,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating application env",
+			err.Error(),
+		)
+		return
+	}
+
+	if createResp.StatusCode() == http.StatusConflict {
+		resp.Diagnostics.AddError(
+			"Application env already exists",
+			fmt.Sprintf("The application %s already has an env with key %s. Import it with ID %s to manage it.",
+				uuid, plan.Key.ValueString(), applicationEnvImportID(plan)),
+		)
+		return
+	}
+
+	if createResp.StatusCode() != http.StatusCreated {
+		if sutil.AddValidationErrors(ctx, &resp.Diagnostics, req.Plan.Schema, nil, "Validation error creating application env", createResp.StatusCode(), createResp.Body) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Unexpected HTTP status code creating application env",
+			fmt.Sprintf("Received %s creating application env: uuid=%s, key=%s. Details: %s", createResp.Status(), uuid, plan.Key.ValueString(), createResp.Body),
+		)
+		return
+	}
+
+	data, ok := r.readFromAPI(ctx, &resp.Diagnostics, client, uuid, plan.Key.ValueString(), plan.IsPreview.ValueBool())
+	if !ok {
+		if !resp.Diagnostics.HasError() {
+			resp.Diagnostics.AddError(
+				"Application env not found",
+				fmt.Sprintf("Application env was not found after creation: uuid=%s, key=%s", uuid, plan.Key.ValueString()),
+			)
+		}
+		return
+	}
+
+	data.Value = flattenWriteOnlyValue(data.Value.ValueStringPointer(), plan.ValueWoVersion)
+	data.ValueWoVersion = plan.ValueWoVersion
+	data.TeamId = plan.TeamId
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	sutil.SetIdentity(ctx, resp.Identity, &resp.Diagnostics, data.Identity())
+}
+
+func (r *applicationEnvResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state applicationEnvResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading application env", map[string]interface{}{
+		"uuid": state.ApplicationUuid.ValueString(),
+		"key":  state.Key.ValueString(),
+	})
+
+	client := r.providerData.ClientForTeam(state.TeamId, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data, ok := r.readFromAPI(ctx, &resp.Diagnostics, client, state.ApplicationUuid.ValueString(), state.Key.ValueString(), state.IsPreview.ValueBool())
+	if !ok {
+		if !resp.Diagnostics.HasError() {
+			resp.State.RemoveResource(ctx)
+		}
+		return
+	}
+
+	data.Value = flattenWriteOnlyValue(data.Value.ValueStringPointer(), state.ValueWoVersion)
+	data.ValueWoVersion = state.ValueWoVersion
+	data.TeamId = state.TeamId
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	sutil.SetIdentity(ctx, resp.Identity, &resp.Diagnostics, data.Identity())
+}
+
+func (r *applicationEnvResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state applicationEnvResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	uuid := plan.ApplicationUuid.ValueString()
+	tflog.Debug(ctx, "Updating application env", map[string]interface{}{
+		"uuid": uuid,
+		"key":  plan.Key.ValueString(),
+	})
+
+	client := r.providerData.ClientForTeam(plan.TeamId, &resp.Diagnostics)
+	value := writeOnlyValueForAPI(ctx, req.Config, &resp.Diagnostics, "value", plan.Value, plan.ValueWoVersion, state.ValueWoVersion)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if value == nil {
+		// The write-only value did not change, so send the value stored in Coolify
+		current, ok := r.readFromAPI(ctx, &resp.Diagnostics, client, uuid, plan.Key.ValueString(), plan.IsPreview.ValueBool())
+		if !ok {
+			if !resp.Diagnostics.HasError() {
+				resp.Diagnostics.AddError(
+					"Application env not found",
+					fmt.Sprintf("Application env was not found: uuid=%s, key=%s", uuid, plan.Key.ValueString()),
+				)
+			}
+			return
+		}
+		value = current.Value.ValueStringPointer()
+	}
+
+	updateResp, err := client.UpdateEnvByApplicationUuidWithResponse(ctx, uuid, api.UpdateEnvByApplicationUuidJSONRequestBody{
+		IsBuildTime: plan.IsBuildTime.ValueBoolPointer(),
+		IsLiteral:   plan.IsLiteral.ValueBoolPointer(),
+		IsMultiline: plan.IsMultiline.ValueBoolPointer(),
+		IsPreview:   plan.IsPreview.ValueBoolPointer(),
+		IsShownOnce: plan.IsShownOnce.ValueBoolPointer(),
+		Key:         plan.Key.ValueString(),
+		Value:       *value,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Error updating application env: uuid=%s, key=%s", uuid, plan.Key.ValueString()),
+			err.Error(),
+		)
+		return
+	}
+
+	if updateResp.StatusCode() != http.StatusCreated {
+		if sutil.AddValidationErrors(ctx, &resp.Diagnostics, req.Plan.Schema, nil, "Validation error updating application env", updateResp.StatusCode(), updateResp.Body) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Unexpected HTTP status code updating application env",
+			fmt.Sprintf("Received %s updating application env: uuid=%s, key=%s. Details: %s", updateResp.Status(), uuid, plan.Key.ValueString(), updateResp.Body))
+		return
+	}
+
+	data, ok := r.readFromAPI(ctx, &resp.Diagnostics, client, uuid, plan.Key.ValueString(), plan.IsPreview.ValueBool())
+	if !ok {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Value = flattenWriteOnlyValue(data.Value.ValueStringPointer(), plan.ValueWoVersion)
+	data.ValueWoVersion = plan.ValueWoVersion
+	data.TeamId = plan.TeamId
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	sutil.SetIdentity(ctx, resp.Identity, &resp.Diagnostics, data.Identity())
+}
+
+func (r *applicationEnvResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state applicationEnvResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	uuid := state.ApplicationUuid.ValueString()
+	tflog.Debug(ctx, "Deleting application env", map[string]interface{}{
+		"uuid": uuid,
+		"key":  state.Key.ValueString(),
+	})
+
+	client := r.providerData.ClientForTeam(state.TeamId, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteResp, err := client.DeleteEnvByApplicationUuidWithResponse(ctx, uuid, state.Uuid.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete application env, got error: %s", err))
+		return
+	}
+
+	if deleteResp.StatusCode() != http.StatusOK && deleteResp.StatusCode() != http.StatusNotFound {
+		resp.Diagnostics.AddError(
+			"Unexpected HTTP status code deleting application env",
+			fmt.Sprintf("Received %s deleting application env: uuid=%s, key=%s. Details: %s", deleteResp.Status(), uuid, state.Key.ValueString(), deleteResp.Body))
+		return
+	}
+}
+
+func (r *applicationEnvResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"application_uuid": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "UUID of the application.",
+			},
+			"key": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "Key of the environment variable.",
+			},
+			"is_preview": identityschema.BoolAttribute{
+				OptionalForImport: true,
+				Description:       "Whether the environment variable is used in preview deployments.",
+			},
+			"team_id": sutil.TeamIdIdentityAttribute(),
+		},
+	}
+}
+
+func (r *applicationEnvResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID == "" {
+		var identity applicationEnvIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("application_uuid"), identity.ApplicationUuid)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("key"), identity.Key)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("team_id"), identity.TeamId)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("is_preview"), identity.IsPreview.ValueBool())...)
+		return
+	}
+
+	ids := strings.Split(req.ID, "/")
+	isPreview := len(ids) > 2 && ids[2] == "preview"
+	maxIds := 3
+	if isPreview {
+		maxIds = 4
+	}
+	if len(ids) < 2 || len(ids) > maxIds || ids[0] == "" || ids[1] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			"Import ID should be in the format: <application_uuid>/<key> or <application_uuid>/<key>/preview, optionally followed by /<team_id>",
+		)
+		return
+	}
+	if len(ids) == maxIds {
+		sutil.SetImportTeamId(ctx, ids[maxIds-1], resp)
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("application_uuid"), ids[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("key"), ids[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("is_preview"), isPreview)...)
+}
+
+func (r *applicationEnvResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.providerData != nil {
+		r.providerData.CheckPlannedTeam(ctx, req.Plan, &resp.Diagnostics)
+	}
+}
+
+// MARK: Helper Functions
+
+func applicationEnvImportID(m applicationEnvResourceModel) string {
+	id := m.ApplicationUuid.ValueString() + "/" + m.Key.ValueString()
+	if m.IsPreview.ValueBool() {
+		id += "/preview"
+	}
+	if !m.TeamId.IsNull() {
+		id += "/" + m.TeamId.String()
+	}
+	return id
+}
+
+// readFromAPI finds the env identified by key and preview flag, returning
+// false when either the application or the env does not exist.
+func (r *applicationEnvResource) readFromAPI(
+	ctx context.Context,
+	diags *diag.Diagnostics,
+	client *api.ClientWithResponses,
+	uuid string,
+	key string,
+	isPreview bool,
+) (applicationEnvResourceModel, bool) {
+	readResp, err := client.ListEnvsByApplicationUuidWithResponse(ctx, uuid)
+	if err != nil {
+		diags.AddError(
+			fmt.Sprintf("Error reading application envs: uuid=%s", uuid),
+			err.Error(),
+		)
+		return applicationEnvResourceModel{}, false
+	}
+
+	if readResp.StatusCode() == http.StatusNotFound {
+		return applicationEnvResourceModel{}, false
+	}
+
+	if readResp.StatusCode() != http.StatusOK {
+		diags.AddError(
+			"Unexpected HTTP status code reading application envs",
+			fmt.Sprintf("Received %s for application envs: uuid=%s. Details: %s", readResp.Status(), uuid, readResp.Body))
+		return applicationEnvResourceModel{}, false
+	}
+
+	for _, env := range *readResp.JSON200 {
+		if env.Key == nil || *env.Key != key || (env.IsPreview != nil && *env.IsPreview) != isPreview {
+			continue
+		}
+
+		return applicationEnvResourceModel{
+			ApplicationEnvsModel: resource_application_envs.ApplicationEnvsModel{
+				IsBuildTime: flatten.Bool(env.IsBuildTime),
+				IsLiteral:   flatten.Bool(env.IsLiteral),
+				IsMultiline: flatten.Bool(env.IsMultiline),
+				IsPreview:   types.BoolValue(isPreview),
+				IsShownOnce: flatten.Bool(env.IsShownOnce),
+				Key:         flatten.String(env.Key),
+				Uuid:        flatten.String(env.Uuid),
+				Value:       flatten.String(env.Value),
+			},
+			ApplicationUuid: types.StringValue(uuid),
+		}, true
+	}
+
+	return applicationEnvResourceModel{}, false
+}
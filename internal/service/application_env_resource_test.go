@@ -0,0 +1,228 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"terraform-provider-coolify/internal/acctest"
+	"terraform-provider-coolify/internal/service"
+)
+
+func TestAccApplicationEnvResource(t *testing.T) {
+	resName := "coolify_application_env.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{ // Create and Read testing
+				Config: `
+					resource "coolify_application_env" "test" {
+						application_uuid = "` + acctest.ApplicationUUID + `"
+						key              = "TF_ACC_SINGLE"
+						value            = "value1"
+					}`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue(resName, tfjsonpath.New("is_build_time"), knownvalue.Bool(false)),
+						plancheck.ExpectKnownValue(resName, tfjsonpath.New("is_literal"), knownvalue.Bool(false)),
+						plancheck.ExpectKnownValue(resName, tfjsonpath.New("is_multiline"), knownvalue.Bool(false)),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resName, "application_uuid", acctest.ApplicationUUID),
+					resource.TestCheckResourceAttr(resName, "key", "TF_ACC_SINGLE"),
+					resource.TestCheckResourceAttr(resName, "value", "value1"),
+					resource.TestCheckResourceAttr(resName, "is_preview", "false"),
+					resource.TestCheckResourceAttrSet(resName, "uuid"),
+				),
+			},
+			{ // ImportState testing
+				ResourceName:                         resName,
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateId:                        acctest.ApplicationUUID + "/TF_ACC_SINGLE",
+				ImportStateVerifyIdentifierAttribute: "uuid",
+			},
+			{ // Update and Read testing
+				Config: `
+					resource "coolify_application_env" "test" {
+						application_uuid = "` + acctest.ApplicationUUID + `"
+						key              = "TF_ACC_SINGLE"
+						value            = "value2"
+					}`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resName, plancheck.ResourceActionUpdate),
+					},
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resName, plancheck.ResourceActionNoop),
+					},
+				},
+				Check: resource.TestCheckResourceAttr(resName, "value", "value2"),
+			},
+		},
+	})
+}
+
+func TestAccApplicationEnvResource_Identity(t *testing.T) {
+	resName := "coolify_application_env.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			{ // Create and Read testing
+				Config: `
+					resource "coolify_application_env" "test" {
+						application_uuid = "` + acctest.ApplicationUUID + `"
+						key              = "TF_ACC_SINGLE"
+						value            = "value1"
+					}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentity(resName, map[string]knownvalue.Check{
+						"application_uuid": knownvalue.StringExact(acctest.ApplicationUUID),
+						"key":              knownvalue.StringExact("TF_ACC_SINGLE"),
+						"is_preview":       knownvalue.Bool(false),
+						"team_id":          knownvalue.Null(),
+					}),
+				},
+			},
+			{ // ImportState by identity testing
+				ResourceName:    resName,
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
+
+func TestAccApplicationEnvResource_WriteOnlyValue(t *testing.T) {
+	resName := "coolify_application_env.test"
+	config := func(value string, version int) string {
+		return fmt.Sprintf(`
+		resource "coolify_application_env" "test" {
+			application_uuid = "%s"
+			key              = "TF_ACC_SINGLE_WO"
+			value_wo         = "%s"
+			value_wo_version = %d
+		}
+		`, acctest.ApplicationUUID, value, version)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{ // Create with a write-only value
+				Config: config("secret", 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr(resName, "value"),
+					resource.TestCheckNoResourceAttr(resName, "value_wo"),
+					resource.TestCheckResourceAttr(resName, "value_wo_version", "1"),
+					resource.TestCheckResourceAttrSet(resName, "uuid"),
+				),
+			},
+			{ // Changing the value without bumping the version is a no-op
+				Config: config("secret-changed", 1),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resName, plancheck.ResourceActionNoop),
+					},
+				},
+			},
+			{ // Bumping the version updates the value
+				Config: config("secret-changed", 2),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resName, plancheck.ResourceActionUpdate),
+					},
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resName, plancheck.ResourceActionNoop),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr(resName, "value"),
+					resource.TestCheckResourceAttr(resName, "value_wo_version", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestApplicationEnvResourceSchema(t *testing.T) {
+	ctx := context.Background()
+	rs := service.NewApplicationEnvResource()
+	resp := &tfresource.SchemaResponse{}
+	rs.Schema(ctx, tfresource.SchemaRequest{}, resp)
+
+	if diags := resp.Schema.ValidateImplementation(ctx); diags.HasError() {
+		t.Fatalf("schema validation diagnostics: %+v", diags)
+	}
+}
+
+func TestApplicationEnvResourceImportState(t *testing.T) {
+	ctx := context.Background()
+	rs := service.NewApplicationEnvResource()
+	schemaResp := &tfresource.SchemaResponse{}
+	rs.Schema(ctx, tfresource.SchemaRequest{}, schemaResp)
+
+	tests := []struct {
+		name              string
+		id                string
+		expectedKey       types.String
+		expectedIsPreview types.Bool
+		expectedTeamId    types.Int64
+		expectedError     bool
+	}{
+		{name: "key", id: "abc1234/KEY", expectedKey: types.StringValue("KEY"), expectedIsPreview: types.BoolValue(false), expectedTeamId: types.Int64Null()},
+		{name: "preview", id: "abc1234/KEY/preview", expectedKey: types.StringValue("KEY"), expectedIsPreview: types.BoolValue(true), expectedTeamId: types.Int64Null()},
+		{name: "team", id: "abc1234/KEY/3", expectedKey: types.StringValue("KEY"), expectedIsPreview: types.BoolValue(false), expectedTeamId: types.Int64Value(3)},
+		{name: "preview and team", id: "abc1234/KEY/preview/3", expectedKey: types.StringValue("KEY"), expectedIsPreview: types.BoolValue(true), expectedTeamId: types.Int64Value(3)},
+		{name: "invalid team", id: "abc1234/KEY/team", expectedError: true},
+		{name: "missing key", id: "abc1234", expectedError: true},
+		{name: "too many segments", id: "abc1234/KEY/3/4", expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &tfresource.ImportStateResponse{
+				State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+			}
+			rs.(tfresource.ResourceWithImportState).ImportState(ctx, tfresource.ImportStateRequest{ID: tt.id}, resp)
+			require.Equal(t, tt.expectedError, resp.Diagnostics.HasError(), resp.Diagnostics)
+			if tt.expectedError {
+				return
+			}
+
+			var key types.String
+			var isPreview types.Bool
+			var teamId types.Int64
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("key"), &key)...)
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("is_preview"), &isPreview)...)
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("team_id"), &teamId)...)
+			assert.Equal(t, tt.expectedKey, key)
+			assert.Equal(t, tt.expectedIsPreview, isPreview)
+			assert.Equal(t, tt.expectedTeamId, teamId)
+		})
+	}
+}
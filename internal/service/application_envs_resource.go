@@ -6,11 +6,13 @@ import (
 	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
@@ -18,12 +20,16 @@ import (
 	"terraform-provider-coolify/internal/flatten"
 	"terraform-provider-coolify/internal/provider/generated/resource_application_envs"
 	"terraform-provider-coolify/internal/provider/util"
+	sutil "terraform-provider-coolify/internal/service/util"
 )
 
 var (
-	_ resource.Resource                = &applicationEnvsResource{}
-	_ resource.ResourceWithConfigure   = &applicationEnvsResource{}
-	_ resource.ResourceWithImportState = &applicationEnvsResource{}
+	_ resource.Resource                 = &applicationEnvsResource{}
+	_ resource.ResourceWithConfigure    = &applicationEnvsResource{}
+	_ resource.ResourceWithImportState  = &applicationEnvsResource{}
+	_ resource.ResourceWithIdentity     = &applicationEnvsResource{}
+	_ resource.ResourceWithUpgradeState = &applicationEnvsResource{}
+	_ resource.ResourceWithModifyPlan   = &applicationEnvsResource{}
 )
 
 func NewApplicationEnvsResource() resource.Resource {
@@ -31,13 +37,10 @@ func NewApplicationEnvsResource() resource.Resource {
 }
 
 type applicationEnvsResource struct {
-	client *api.ClientWithResponses
+	providerData *util.ProviderData
 }
 
-type applicationEnvsResourceModel struct {
-	Uuid types.String                                     `tfsdk:"uuid"`
-	Env  []resource_application_envs.ApplicationEnvsModel `tfsdk:"env"`
-}
+type applicationEnvsResourceModel = envsResourceModel
 
 // Type alias for the anonymous struct used in the generated API code
 type updateEnvsByApplicationUuidJSONRequestBodyItem = struct {
@@ -59,6 +62,7 @@ func (r *applicationEnvsResource) Schema(ctx context.Context, req resource.Schem
 
 	resp.Schema = schema.Schema{
 		Description: "Create, read, update, and delete Application environment variables.",
+		Version:     1,
 
 		Attributes: map[string]schema.Attribute{
 			"uuid": schema.StringAttribute{
@@ -68,23 +72,36 @@ func (r *applicationEnvsResource) Schema(ctx context.Context, req resource.Schem
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"dotenv": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Environment variables in dotenv format, e.g. the contents of a `.env` file. Supports `export` prefixes, comments, and single or double quoted values, which may span multiple lines. Variables that span multiple lines are marked as multiline. An `env` block with the same key takes precedence.",
+				Validators:          []validator.String{dotenvValidator{}},
+			},
+			"exclusive": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Manage all environment variables of the application. Variables not declared in an `env` block or in `dotenv` are reported as drift and deleted on apply. Defaults to `false`, which ignores unmanaged variables.",
+			},
+			"env_uuids": envUuidsAttribute(),
+			"team_id":   sutil.TeamIdAttribute(),
 		},
 		Blocks: map[string]schema.Block{
-			"env": schema.ListNestedBlock{
-				MarkdownDescription: "Environment variable to set.",
+			"env": schema.SetNestedBlock{
+				MarkdownDescription: "Environment variable to set. Each combination of `key` and `is_preview` must be unique. " +
+					"Write-only values are not supported here, as Terraform does not allow write-only attributes within sets. Use `coolify_application_env` with `value_wo` to keep a value out of state.",
 				NestedObject: schema.NestedBlockObject{
-					Attributes: codegenSchema.Attributes,
+					Attributes: envsBlockAttributes(codegenSchema.Attributes),
 				},
+				Validators: []validator.Set{uniqueEnvKeysValidator{}},
 			},
 		},
 	}
-
-	makeResourceAttributeRequired(codegenSchema.Attributes, "key")
-	makeResourceAttributeRequired(codegenSchema.Attributes, "value")
 }
 
 func (r *applicationEnvsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
-	util.ProviderDataFromResourceConfigureRequest(req, &r.client, resp)
+	util.ProviderDataFromResourceConfigureRequest(req, &r.providerData, resp)
 }
 
 func (r *applicationEnvsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -99,40 +116,23 @@ func (r *applicationEnvsResource) Create(ctx context.Context, req resource.Creat
 		"uuid": plan.Uuid.ValueString(),
 	})
 
-	uuid := plan.Uuid.ValueString()
-	for i, env := range plan.Env {
-		createResp, err := r.client.CreateEnvByApplicationUuidWithResponse(ctx, uuid, api.CreateEnvByApplicationUuidJSONRequestBody{
-			IsBuildTime: env.IsBuildTime.ValueBoolPointer(),
-			IsLiteral:   env.IsLiteral.ValueBoolPointer(),
-			IsPreview:   env.IsPreview.ValueBoolPointer(),
-			Key:         env.Key.ValueStringPointer(),
-			Value:       env.Value.ValueStringPointer(),
-		})
-
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error creating application envs",
-				err.Error(),
-			)
-			return
-		}
-
-		if createResp.StatusCode() != http.StatusCreated {
-			resp.Diagnostics.AddError(
-				"Unexpected HTTP status code creating application envs",
-				fmt.Sprintf("Received %s creating application envs. Details: %s", createResp.Status(), createResp.Body),
-			)
-			return
-		}
+	client := r.providerData.ClientForTeam(plan.TeamId, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-		// Set the UUID from the response
-		plan.Env[i].Uuid = types.StringPointerValue(createResp.JSON201.Uuid)
+	uuid := plan.Uuid.ValueString()
+	envs, ok := plan.managedEnvs(&resp.Diagnostics)
+	if !ok {
+		return
 	}
 
-	data, _ := r.readFromAPI(ctx, &resp.Diagnostics, uuid)
-	data.Env = r.filterRelevantEnvs(plan.Env, data.Env)
+	if plan.Exclusive.ValueBool() && !r.deleteUnmanagedEnvs(ctx, &resp.Diagnostics, client, uuid, envs) {
+		return
+	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	applied := r.bulkUpdateEnvs(ctx, &resp.Diagnostics, client, uuid, envs)
+	r.saveState(ctx, &resp.Diagnostics, client, &resp.State, resp.Identity, plan, nil, applied)
 }
 
 func (r *applicationEnvsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -151,15 +151,23 @@ func (r *applicationEnvsResource) Read(ctx context.Context, req resource.ReadReq
 		return
 	}
 
-	data, ok := r.readFromAPI(ctx, &resp.Diagnostics, state.Uuid.ValueString())
+	client := r.providerData.ClientForTeam(state.TeamId, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data, ok := r.readFromAPI(ctx, &resp.Diagnostics, client, state.Uuid.ValueString())
 	if !ok {
 		resp.State.RemoveResource(ctx)
 		return
 	}
-	if len(state.Env) > 0 {
-		data.Env = r.filterRelevantEnvs(state.Env, data.Env)
+	if len(state.Env) > 0 || !state.Dotenv.IsNull() {
+		refreshEnvs(&data, state)
 	}
+	data.Exclusive = types.BoolValue(state.Exclusive.ValueBool())
+	data.TeamId = state.TeamId
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	sutil.SetIdentity(ctx, resp.Identity, &resp.Diagnostics, sutil.UuidIdentityModel{Uuid: data.Uuid, TeamId: data.TeamId})
 }
 
 func (r *applicationEnvsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -177,43 +185,199 @@ func (r *applicationEnvsResource) Update(ctx context.Context, req resource.Updat
 	}
 
 	uuid := plan.Uuid.ValueString()
+	planEnvs, ok := plan.managedEnvs(&resp.Diagnostics)
+	if !ok {
+		return
+	}
+	stateEnvs, ok := state.managedEnvs(&resp.Diagnostics)
+	if !ok {
+		return
+	}
 
 	// Update API call logic
 	tflog.Debug(ctx, "Updating application envs", map[string]interface{}{
 		"uuid": uuid,
 	})
+	client := r.providerData.ClientForTeam(plan.TeamId, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	// Create a map of current state envs for fast lookup
-	stateEnvs := make(map[string]resource_application_envs.ApplicationEnvsModel)
-	for _, env := range state.Env {
-		key := fmt.Sprintf("%s-%t", env.Key.ValueString(), env.IsPreview.ValueBool())
-		stateEnvs[key] = env
+	var applied bool
+	if plan.Exclusive.ValueBool() {
+		// Delete every env not in plan, including those not tracked in state
+		applied = r.deleteUnmanagedEnvs(ctx, &resp.Diagnostics, client, uuid, planEnvs)
+	} else {
+		// Delete envs that are in state but not in plan
+		applied = r.deleteRemovedEnvs(ctx, &resp.Diagnostics, client, state, stateEnvs, planEnvs)
 	}
+	applied = applied && r.bulkUpdateEnvs(ctx, &resp.Diagnostics, client, uuid, planEnvs)
 
-	// Create a map of plan envs for fast lookup
-	planEnvs := make(map[string]resource_application_envs.ApplicationEnvsModel)
-	for _, env := range plan.Env {
-		key := fmt.Sprintf("%s-%t", env.Key.ValueString(), env.IsPreview.ValueBool())
-		planEnvs[key] = env
+	r.saveState(ctx, &resp.Diagnostics, client, &resp.State, resp.Identity, plan, stateEnvs, applied)
+}
+
+func (r *applicationEnvsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state applicationEnvsResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	// Delete envs that are in state but not in plan
-	for key, env := range stateEnvs {
-		if _, exists := planEnvs[key]; !exists {
-			_, err := r.client.DeleteEnvByApplicationUuidWithResponse(ctx, uuid, env.Uuid.ValueString())
+	tflog.Debug(ctx, "Deleting application envs", map[string]interface{}{
+		"uuid": state.Uuid.ValueString(),
+	})
+
+	client := r.providerData.ClientForTeam(state.TeamId, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	envs, ok := state.managedEnvs(&resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	envUuids, ok := r.stateEnvUuids(ctx, &resp.Diagnostics, client, state)
+	if !ok {
+		return
+	}
+
+	for _, env := range envs {
+		if envUuid, exists := envUuids[env.envKey()]; exists {
+			resp.Diagnostics.Append(r.deleteFromAPI(ctx, client, state.Uuid.ValueString(), envUuid)...)
+		}
+	}
+
+	if resp.Diagnostics.HasError() {
+		// Keep tracking the envs that could not be deleted
+		r.saveState(ctx, &resp.Diagnostics, client, &resp.State, nil, state, nil, false)
+	}
+}
+
+func (r *applicationEnvsResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = sutil.UuidIdentitySchema("application")
+}
+
+func (r *applicationEnvsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	sutil.ImportUuidState(ctx, req, resp)
+}
+
+func (r *applicationEnvsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.providerData != nil {
+		r.providerData.CheckPlannedTeam(ctx, req.Plan, &resp.Diagnostics)
+	}
+}
+
+func (r *applicationEnvsResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 stored env as an ordered list, including the UUID of each variable
+		0: {
+			PriorSchema: envsResourceSchemaV0(resource_application_envs.ApplicationEnvsResourceSchema(ctx).Attributes),
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior envsResourceModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				upgraded := upgradeEnvsV0(prior)
+				resp.Diagnostics.Append(resp.State.Set(ctx, &upgraded)...)
+			},
+		},
+	}
+}
+
+// MARK: Helper Functions
+
+// deleteUnmanagedEnvs deletes every env of the application that is not in the
+// given plan envs, returning whether it succeeded.
+func (r *applicationEnvsResource) deleteUnmanagedEnvs(
+	ctx context.Context,
+	diags *diag.Diagnostics,
+	client *api.ClientWithResponses,
+	uuid string,
+	planEnvs []envsResourceEnvModel,
+) bool {
+	apiEnvs, ok := r.listEnvs(ctx, diags, client, uuid)
+	if !ok {
+		return false
+	}
+
+	planKeys := make(map[string]bool)
+	for _, env := range planEnvs {
+		planKeys[env.envKey()] = true
+	}
+
+	for _, env := range *apiEnvs {
+		key := envKey(flatten.String(env.Key), flatten.Bool(env.IsPreview))
+		if !planKeys[key] && env.Uuid != nil {
+			tflog.Debug(ctx, "Deleting unmanaged application env", map[string]interface{}{
+				"uuid": uuid,
+				"key":  flatten.String(env.Key).ValueString(),
+			})
+			diags.Append(r.deleteFromAPI(ctx, client, uuid, *env.Uuid)...)
+		}
+	}
+
+	return !diags.HasError()
+}
+
+// deleteRemovedEnvs deletes the envs of stateEnvs that are not in planEnvs,
+// returning whether it succeeded.
+func (r *applicationEnvsResource) deleteRemovedEnvs(
+	ctx context.Context,
+	diags *diag.Diagnostics,
+	client *api.ClientWithResponses,
+	state applicationEnvsResourceModel,
+	stateEnvs []envsResourceEnvModel,
+	planEnvs []envsResourceEnvModel,
+) bool {
+	uuid := state.Uuid.ValueString()
+	envUuids, ok := r.stateEnvUuids(ctx, diags, client, state)
+	if !ok {
+		return false
+	}
+
+	planKeys := make(map[string]bool)
+	for _, env := range planEnvs {
+		planKeys[env.envKey()] = true
+	}
+
+	for _, env := range stateEnvs {
+		key := env.envKey()
+		if envUuid, exists := envUuids[key]; exists && !planKeys[key] {
+			_, err := client.DeleteEnvByApplicationUuidWithResponse(ctx, uuid, envUuid)
 			if err != nil {
-				resp.Diagnostics.AddError(
+				diags.AddError(
 					fmt.Sprintf("Error deleting application env: key=%s, uuid=%s", key, uuid),
 					err.Error(),
 				)
-				return
+				return false
 			}
 		}
 	}
 
-	var bulkUpdateEnvs = []updateEnvsByApplicationUuidJSONRequestBodyItem{}
-	for _, env := range plan.Env {
-		bulkUpdateEnvs = append(bulkUpdateEnvs, updateEnvsByApplicationUuidJSONRequestBodyItem{
+	return true
+}
+
+// bulkUpdateEnvs creates or updates all given envs in a single request,
+// returning whether it succeeded.
+func (r *applicationEnvsResource) bulkUpdateEnvs(
+	ctx context.Context,
+	diags *diag.Diagnostics,
+	client *api.ClientWithResponses,
+	uuid string,
+	envs []envsResourceEnvModel,
+) bool {
+	if len(envs) == 0 {
+		return true
+	}
+
+	bulkUpdateEnvs := make([]updateEnvsByApplicationUuidJSONRequestBodyItem, len(envs))
+	for i, env := range envs {
+		bulkUpdateEnvs[i] = updateEnvsByApplicationUuidJSONRequestBodyItem{
 			IsBuildTime: env.IsBuildTime.ValueBoolPointer(),
 			IsLiteral:   env.IsLiteral.ValueBoolPointer(),
 			IsPreview:   env.IsPreview.ValueBoolPointer(),
@@ -221,102 +385,140 @@ func (r *applicationEnvsResource) Update(ctx context.Context, req resource.Updat
 			Value:       env.Value.ValueStringPointer(),
 			IsMultiline: env.IsMultiline.ValueBoolPointer(),
 			IsShownOnce: env.IsShownOnce.ValueBoolPointer(),
-		})
+		}
 	}
 
-	if len(bulkUpdateEnvs) > 0 {
-		updateResp, err := r.client.UpdateEnvsByApplicationUuidWithResponse(ctx, uuid, api.UpdateEnvsByApplicationUuidJSONRequestBody{
-			Data: bulkUpdateEnvs,
-		})
-
-		if err != nil {
-			resp.Diagnostics.AddError(
-				fmt.Sprintf("Error updating application envs: uuid=%s", uuid),
-				err.Error(),
-			)
-			return
-		}
+	updateResp, err := client.UpdateEnvsByApplicationUuidWithResponse(ctx, uuid, api.UpdateEnvsByApplicationUuidJSONRequestBody{
+		Data: bulkUpdateEnvs,
+	})
+	if err != nil {
+		diags.AddError(
+			fmt.Sprintf("Error updating application envs: uuid=%s", uuid),
+			err.Error(),
+		)
+		return false
+	}
 
-		if updateResp.StatusCode() != http.StatusCreated {
-			resp.Diagnostics.AddError(
-				"Unexpected HTTP status code updating application envs",
-				fmt.Sprintf("Received %s updating application envs: uuid=%s. Details: %s", updateResp.Status(), uuid, updateResp.Body))
-			return
+	if updateResp.StatusCode() != http.StatusCreated {
+		if sutil.AddValidationErrors(ctx, diags, nil, nil, "Validation error updating application envs", updateResp.StatusCode(), updateResp.Body) {
+			return false
 		}
+		diags.AddError(
+			"Unexpected HTTP status code updating application envs",
+			fmt.Sprintf("Received %s updating application envs: uuid=%s. Details: %s", updateResp.Status(), uuid, updateResp.Body))
+		return false
 	}
 
-	data, ok := r.readFromAPI(ctx, &resp.Diagnostics, uuid)
-	if !ok {
-		resp.State.RemoveResource(ctx)
-		return
-	}
-	data.Env = r.filterRelevantEnvs(plan.Env, data.Env)
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	return true
 }
 
-func (r *applicationEnvsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var state applicationEnvsResourceModel
-
-	// Read Terraform prior state data into the model
-	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
-	if resp.Diagnostics.HasError() {
+// saveState reads the envs back from the API and saves those managed by plan
+// to state. When the apply failed part way, priorEnvs are kept tracked as well
+// and the dotenv content reflects what actually exists, so that whatever was
+// created is not lost and the remaining changes are planned again. The
+// resource identity is set as well, unless identity is nil.
+func (r *applicationEnvsResource) saveState(
+	ctx context.Context,
+	diags *diag.Diagnostics,
+	client *api.ClientWithResponses,
+	state *tfsdk.State,
+	identity *tfsdk.ResourceIdentity,
+	plan applicationEnvsResourceModel,
+	priorEnvs []envsResourceEnvModel,
+	applied bool,
+) {
+	data, ok := r.readFromAPI(ctx, diags, client, plan.Uuid.ValueString())
+	if !ok {
+		if !diags.HasError() {
+			state.RemoveResource(ctx)
+		}
 		return
 	}
 
-	tflog.Debug(ctx, "Deleting application envs", map[string]interface{}{
-		"uuid": state.Uuid.ValueString(),
-	})
-
-	for _, env := range state.Env {
-		resp.Diagnostics.Append(r.deleteFromAPI(ctx, state.Uuid.ValueString(), env.Uuid.ValueString())...)
+	tracked := plan
+	if !applied {
+		tracked.Env = mergeEnvs(plan.Env, priorEnvs)
 	}
+	refreshEnvs(&data, tracked)
+	if applied {
+		data.Dotenv = plan.Dotenv
+	}
+	data.Exclusive = plan.Exclusive
+	data.TeamId = plan.TeamId
+	diags.Append(state.Set(ctx, &data)...)
+	sutil.SetIdentity(ctx, identity, diags, sutil.UuidIdentityModel{Uuid: data.Uuid, TeamId: data.TeamId})
 }
 
-func (r *applicationEnvsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("uuid"), req, resp)
+func (r *applicationEnvsResource) deleteFromAPI(
+	ctx context.Context,
+	client *api.ClientWithResponses,
+	uuid string,
+	envUuid string,
+) (diags diag.Diagnostics) {
+	_, err := client.DeleteEnvByApplicationUuidWithResponse(ctx, uuid, envUuid)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to delete application envs, got error: %s", err))
+	}
+	return diags
 }
 
-// MARK: Helper Functions
-
-func (r *applicationEnvsResource) filterRelevantEnvs(
-	stateEnvs []resource_application_envs.ApplicationEnvsModel,
-	apiEnvs []resource_application_envs.ApplicationEnvsModel,
-) []resource_application_envs.ApplicationEnvsModel {
-	apiEnvMap := make(map[string]resource_application_envs.ApplicationEnvsModel)
-	for _, env := range apiEnvs {
-		key := fmt.Sprintf("%s-%t", env.Key.ValueString(), env.IsPreview.ValueBool())
-		apiEnvMap[key] = env
+// stateEnvUuids returns the env UUIDs recorded in state, listing the envs of
+// the application when none were recorded.
+func (r *applicationEnvsResource) stateEnvUuids(
+	ctx context.Context,
+	diags *diag.Diagnostics,
+	client *api.ClientWithResponses,
+	state applicationEnvsResourceModel,
+) (map[string]string, bool) {
+	if envUuids, ok := state.envUuids(); ok {
+		return envUuids, true
 	}
 
-	var filteredEnvs []resource_application_envs.ApplicationEnvsModel
-	for _, env := range stateEnvs {
-		key := fmt.Sprintf("%s-%t", env.Key.ValueString(), env.IsPreview.ValueBool())
-		if apiEnv, exists := apiEnvMap[key]; exists {
-			filteredEnvs = append(filteredEnvs, apiEnv)
-		}
+	apiEnvs, ok := r.listEnvs(ctx, diags, client, state.Uuid.ValueString())
+	if !ok {
+		return nil, false
 	}
-
-	return filteredEnvs
+	return envUuidsByKey(apiEnvs), true
 }
 
-func (r *applicationEnvsResource) deleteFromAPI(
+// listEnvs lists the envs of the application. An application that no longer exists
+// has no envs.
+func (r *applicationEnvsResource) listEnvs(
 	ctx context.Context,
+	diags *diag.Diagnostics,
+	client *api.ClientWithResponses,
 	uuid string,
-	envUuid string,
-) (diags diag.Diagnostics) {
-	_, err := r.client.DeleteEnvByApplicationUuidWithResponse(ctx, uuid, envUuid)
+) (*[]api.EnvironmentVariable, bool) {
+	readResp, err := client.ListEnvsByApplicationUuidWithResponse(ctx, uuid)
 	if err != nil {
-		diags.AddError("Client Error", fmt.Sprintf("Unable to delete application envs, got error: %s", err))
+		diags.AddError(
+			fmt.Sprintf("Error reading application envs: uuid=%s", uuid),
+			err.Error(),
+		)
+		return nil, false
 	}
-	return diags
+
+	if readResp.StatusCode() == http.StatusNotFound {
+		return &[]api.EnvironmentVariable{}, true
+	}
+
+	if readResp.StatusCode() != http.StatusOK {
+		diags.AddError(
+			"Unexpected HTTP status code reading application envs",
+			fmt.Sprintf("Received %s for application envs: uuid=%s. Details: %s", readResp.Status(), uuid, readResp.Body))
+		return nil, false
+	}
+
+	return readResp.JSON200, true
 }
 
 func (r *applicationEnvsResource) readFromAPI(
 	ctx context.Context,
 	diags *diag.Diagnostics,
+	client *api.ClientWithResponses,
 	uuid string,
 ) (applicationEnvsResourceModel, bool) {
-	readResp, err := r.client.ListEnvsByApplicationUuidWithResponse(ctx, uuid)
+	readResp, err := client.ListEnvsByApplicationUuidWithResponse(ctx, uuid)
 	if err != nil {
 		diags.AddError(
 			fmt.Sprintf("Error reading application envs: uuid=%s", uuid),
@@ -346,22 +548,9 @@ func (r *applicationEnvsResource) apiToModel(
 	_ *diag.Diagnostics,
 	response *[]api.EnvironmentVariable,
 ) applicationEnvsResourceModel {
-	envs := make([]resource_application_envs.ApplicationEnvsModel, len(*response))
-	for i, env := range *response {
-		envs[i] = resource_application_envs.ApplicationEnvsModel{
-			IsBuildTime: flatten.Bool(env.IsBuildTime),
-			IsLiteral:   flatten.Bool(env.IsLiteral),
-			IsMultiline: flatten.Bool(env.IsMultiline),
-			IsPreview:   flatten.Bool(env.IsPreview),
-			IsShownOnce: flatten.Bool(env.IsShownOnce),
-			Key:         flatten.String(env.Key),
-			Uuid:        flatten.String(env.Uuid),
-			Value:       flatten.String(env.Value),
-		}
-	}
-
 	return applicationEnvsResourceModel{
-		Uuid: types.StringUnknown(),
-		Env:  envs,
+		Uuid:     types.StringUnknown(),
+		Env:      flattenEnvs(response),
+		EnvUuids: envUuidsValue(envUuidsByKey(response)),
 	}
 }
@@ -3,12 +3,16 @@ package service_test
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
 	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 
 	"terraform-provider-coolify/internal/acctest"
 	"terraform-provider-coolify/internal/service"
@@ -40,8 +44,11 @@ func TestAccApplicationEnvsResource(t *testing.T) {
 					}`,
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttrSet(resName, "env.#"),
-					resource.TestCheckResourceAttr(resName, "env.0.key", "key1"),
-					resource.TestCheckResourceAttr(resName, "env.0.value", "value1"),
+					resource.TestCheckTypeSetElemNestedAttrs(resName, "env.*", map[string]string{"key": "key1", "value": "value1"}),
+					resource.TestCheckTypeSetElemNestedAttrs(resName, "env.*", map[string]string{"key": "key2", "value": "value2"}),
+					resource.TestCheckResourceAttr(resName, "env_uuids.%", "3"),
+					resource.TestCheckResourceAttrSet(resName, "env_uuids.key1"),
+					resource.TestCheckResourceAttrSet(resName, "env_uuids.key1:preview"),
 				),
 			},
 			{ // ImportState testing
@@ -88,10 +95,107 @@ func TestAccApplicationEnvsResource(t *testing.T) {
 				},
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttrSet(resName, "env.#"),
-					resource.TestCheckResourceAttr(resName, "env.0.key", "key1-1"),
-					resource.TestCheckResourceAttr(resName, "env.0.value", "value1-1"),
-					resource.TestCheckResourceAttr(resName, "env.1.key", "key2"),
-					resource.TestCheckResourceAttr(resName, "env.1.value", "value2-2"),
+					resource.TestCheckTypeSetElemNestedAttrs(resName, "env.*", map[string]string{"key": "key1-1", "value": "value1-1"}),
+					resource.TestCheckTypeSetElemNestedAttrs(resName, "env.*", map[string]string{"key": "key2", "value": "value2-2"}),
+				),
+			},
+			{ // Reordering envs is a no-op
+				Config: `
+					resource "coolify_application_envs" "test" {
+						uuid = "` + acctest.ApplicationUUID + `"
+						env {
+							key        = "key2"
+							value      = "value2-2"
+						}
+						env {
+							key        = "key1-1"
+							value      = "value1-1"
+						}
+					}`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resName, plancheck.ResourceActionNoop),
+					},
+				},
+			},
+			{ // Duplicate keys are rejected at plan time
+				Config: `
+					resource "coolify_application_envs" "test" {
+						uuid = "` + acctest.ApplicationUUID + `"
+						env {
+							key        = "key2"
+							value      = "value2-2"
+						}
+						env {
+							key        = "key2"
+							value      = "value2-3"
+						}
+					}`,
+				ExpectError: regexp.MustCompile("Duplicate environment variable"),
+			},
+		},
+	})
+}
+
+func TestAccApplicationEnvsResource_Identity(t *testing.T) {
+	resName := "coolify_application_envs.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			{ // Create and Read testing
+				Config: `
+					resource "coolify_application_envs" "test" {
+						uuid = "` + acctest.ApplicationUUID + `"
+						env {
+							key   = "key1"
+							value = "value1"
+						}
+					}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentity(resName, map[string]knownvalue.Check{
+						"uuid":    knownvalue.StringExact(acctest.ApplicationUUID),
+						"team_id": knownvalue.Null(),
+					}),
+				},
+			},
+		},
+	})
+}
+
+func TestAccApplicationEnvsResource_Dotenv(t *testing.T) {
+	resName := "coolify_application_envs.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "coolify_application_envs" "test" {
+						uuid   = "` + acctest.ApplicationUUID + `"
+						dotenv = <<-EOT
+							# comment
+							export DOTENV_KEY1=value1
+							DOTENV_KEY2="multi
+							line"
+							DOTENV_KEY3=overridden
+						EOT
+						env {
+							key   = "DOTENV_KEY3"
+							value = "value3"
+						}
+					}`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resName, plancheck.ResourceActionNoop),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resName, "env.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(resName, "env.*", map[string]string{"key": "DOTENV_KEY3", "value": "value3"}),
 				),
 			},
 		},
@@ -1,14 +1,25 @@
 package service
 
 import (
+	"context"
 	"fmt"
+	"net/url"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	datasource_schema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	resource_schema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-coolify/internal/expand"
+	"terraform-provider-coolify/internal/flatten"
 )
 
 func makeResourceAttributeRequired(
@@ -145,3 +156,99 @@ func makeResourceAttributeNonEmpty(
 
 	return nil
 }
+
+// writeOnlyAttributes returns the schema for a sensitive string attribute along
+// with its write-only variant (`<name>_wo`) and the `<name>_wo_version`
+// attribute that triggers sending the write-only value on update. Exactly one
+// of the plain or write-only attributes must be configured.
+func writeOnlyAttributes(name string, description string, versionDescription string) map[string]resource_schema.Attribute {
+	woName := name + "_wo"
+	woVersionName := name + "_wo_version"
+
+	return map[string]resource_schema.Attribute{
+		name: resource_schema.StringAttribute{
+			Optional:    true,
+			Sensitive:   true,
+			Description: description + ". Stored in state, use `" + woName + "` to avoid this.",
+			Validators: []validator.String{
+				stringvalidator.ExactlyOneOf(path.MatchRoot(woName)),
+			},
+		},
+		woName: resource_schema.StringAttribute{
+			Optional:    true,
+			Sensitive:   true,
+			WriteOnly:   true,
+			Description: description + " (write-only). Only sent to Coolify on create or when `" + woVersionName + "` changes.",
+			Validators: []validator.String{
+				stringvalidator.AlsoRequires(path.MatchRoot(woVersionName)),
+			},
+		},
+		woVersionName: resource_schema.Int64Attribute{
+			Optional:    true,
+			Description: "Version of `" + woName + "`. " + versionDescription,
+			Validators: []validator.Int64{
+				int64validator.AlsoRequires(path.MatchRoot(woName)),
+			},
+		},
+	}
+}
+
+// writeOnlyValueForAPI returns the value to send to the API. A value set in the
+// plan always takes precedence. Otherwise the write-only value is read from the
+// config, but only when its version differs from the prior one.
+func writeOnlyValueForAPI(
+	ctx context.Context,
+	config tfsdk.Config,
+	diags *diag.Diagnostics,
+	name string,
+	value types.String,
+	woVersion types.Int64,
+	priorWoVersion types.Int64,
+) *string {
+	if !value.IsNull() {
+		return expand.String(value)
+	}
+
+	if woVersion.Equal(priorWoVersion) {
+		return nil
+	}
+
+	// Write-only values are never present in the plan, only the config
+	var woValue types.String
+	diags.Append(config.GetAttribute(ctx, path.Root(name+"_wo"), &woValue)...)
+
+	return expand.String(woValue)
+}
+
+// flattenWriteOnlyValue returns the value read from the API, unless the
+// write-only variant is in use, in which case it must not be persisted to state.
+func flattenWriteOnlyValue(value *string, woVersion types.Int64) types.String {
+	if !woVersion.IsNull() {
+		return types.StringNull()
+	}
+
+	return flatten.String(value)
+}
+
+// redactUrlPassword removes the password from a connection URL read from the
+// API when the write-only variant of the password is in use, as the URL would
+// otherwise persist the password to state.
+func redactUrlPassword(value types.String, woVersion types.Int64) types.String {
+	if woVersion.IsNull() || value.IsNull() || value.IsUnknown() {
+		return value
+	}
+
+	parsed, err := url.Parse(value.ValueString())
+	if err != nil {
+		// An unparsable URL may still contain the password
+		return types.StringNull()
+	}
+	if parsed.User == nil {
+		return value
+	}
+	if _, hasPassword := parsed.User.Password(); hasPassword {
+		parsed.User = url.User(parsed.User.Username())
+	}
+
+	return types.StringValue(parsed.String())
+}
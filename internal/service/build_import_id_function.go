@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &buildImportIdFunction{}
+
+func NewBuildImportIdFunction() function.Function {
+	return &buildImportIdFunction{}
+}
+
+type buildImportIdFunction struct{}
+
+func (f *buildImportIdFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "build_import_id"
+}
+
+func (f *buildImportIdFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Build the import ID of a service or database",
+		MarkdownDescription: "Builds the four-part `<server_uuid>/<project_uuid>/<environment_name>/<uuid>` ID used to import `coolify_service` and database resources, e.g. in the `id` of an `import` block. " +
+			"When a `team_id` is given, it is appended to import a resource of another team with its token from the provider `team_tokens`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "server_uuid",
+				MarkdownDescription: "UUID of the server the resource is deployed to.",
+			},
+			function.StringParameter{
+				Name:                "project_uuid",
+				MarkdownDescription: "UUID of the project the resource belongs to.",
+			},
+			function.StringParameter{
+				Name:                "environment_name",
+				MarkdownDescription: "Name of the environment the resource belongs to.",
+			},
+			function.StringParameter{
+				Name:                "uuid",
+				MarkdownDescription: "UUID of the resource.",
+			},
+		},
+		VariadicParameter: function.Int64Parameter{
+			Name:                "team_id",
+			MarkdownDescription: "Optional ID of the team owning the resource.",
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *buildImportIdFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	parts := make([]string, 4)
+	var teamIds []int64
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &parts[0], &parts[1], &parts[2], &parts[3], &teamIds))
+	if resp.Error != nil {
+		return
+	}
+
+	if len(teamIds) > 1 {
+		resp.Error = function.NewArgumentFuncError(4, "Only one team ID can be given")
+		return
+	}
+
+	for i, part := range parts {
+		if part == "" {
+			resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(int64(i), "Value must not be empty"))
+		} else if strings.Contains(part, "/") {
+			resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(int64(i), fmt.Sprintf("Value must not contain \"/\", got: %q", part)))
+		}
+	}
+	if resp.Error != nil {
+		return
+	}
+
+	for _, teamId := range teamIds {
+		parts = append(parts, strconv.FormatInt(teamId, 10))
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, strings.Join(parts, "/")))
+}
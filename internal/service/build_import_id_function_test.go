@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildImportIdFunction(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		teamIds   []int64
+		expected  attr.Value
+		expectErr bool
+	}{
+		{
+			name:     "valid",
+			args:     []string{"rg8ks8c", "uoswco88w8swo40k48o8kcwk", "production", "joscs8wg0owkggsc4gwkw84k"},
+			expected: types.StringValue("rg8ks8c/uoswco88w8swo40k48o8kcwk/production/joscs8wg0owkggsc4gwkw84k"),
+		},
+		{
+			name:     "with team",
+			args:     []string{"rg8ks8c", "uoswco88w8swo40k48o8kcwk", "production", "joscs8wg0owkggsc4gwkw84k"},
+			teamIds:  []int64{3},
+			expected: types.StringValue("rg8ks8c/uoswco88w8swo40k48o8kcwk/production/joscs8wg0owkggsc4gwkw84k/3"),
+		},
+		{
+			name:      "several teams",
+			args:      []string{"rg8ks8c", "uoswco88w8swo40k48o8kcwk", "production", "joscs8wg0owkggsc4gwkw84k"},
+			teamIds:   []int64{3, 4},
+			expected:  types.StringUnknown(),
+			expectErr: true,
+		},
+		{
+			name:      "empty part",
+			args:      []string{"rg8ks8c", "", "production", "joscs8wg0owkggsc4gwkw84k"},
+			expected:  types.StringUnknown(),
+			expectErr: true,
+		},
+		{
+			name:      "part with separator",
+			args:      []string{"rg8ks8c", "uoswco88w8swo40k48o8kcwk", "prod/eu", "joscs8wg0owkggsc4gwkw84k"},
+			expected:  types.StringUnknown(),
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := make([]attr.Value, len(tt.args))
+			for i, arg := range tt.args {
+				args[i] = types.StringValue(arg)
+			}
+			teamIds := make([]attr.Value, len(tt.teamIds))
+			teamIdTypes := make([]attr.Type, len(tt.teamIds))
+			for i, teamId := range tt.teamIds {
+				teamIds[i], teamIdTypes[i] = types.Int64Value(teamId), types.Int64Type
+			}
+			args = append(args, types.TupleValueMust(teamIdTypes, teamIds))
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData(args),
+			}
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.StringUnknown()),
+			}
+
+			NewBuildImportIdFunction().Run(context.Background(), req, resp)
+
+			assert.Equal(t, tt.expectErr, resp.Error != nil)
+			assert.Equal(t, tt.expected, resp.Result.Value())
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	sutil "terraform-provider-coolify/internal/service/util"
+)
+
+var _ function.Function = &composeServicesFunction{}
+
+func NewComposeServicesFunction() function.Function {
+	return &composeServicesFunction{}
+}
+
+type composeServicesFunction struct{}
+
+func (f *composeServicesFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "compose_services"
+}
+
+func (f *composeServicesFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "List the services in a compose file",
+		MarkdownDescription: "Returns the names of the services defined in a Docker Compose file, in the order they are defined. These are the keys of the `compose_services` attribute of `coolify_service` once Coolify has parsed the file.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "compose",
+				MarkdownDescription: "Docker Compose file contents, e.g. the `compose` attribute of `coolify_service`.",
+			},
+		},
+		Return: function.ListReturn{ElementType: types.StringType},
+	}
+}
+
+func (f *composeServicesFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var compose string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &compose))
+	if resp.Error != nil {
+		return
+	}
+
+	names, err := sutil.ComposeServiceNames(compose)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid compose file: "+err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, names))
+}
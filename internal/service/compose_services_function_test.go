@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComposeServicesFunction(t *testing.T) {
+	tests := []struct {
+		name      string
+		compose   string
+		expected  attr.Value
+		expectErr bool
+	}{
+		{
+			name:    "valid",
+			compose: "services:\n  web:\n    image: nginx\n  db:\n    image: postgres\n",
+			expected: types.ListValueMust(types.StringType, []attr.Value{
+				types.StringValue("web"),
+				types.StringValue("db"),
+			}),
+		},
+		{
+			name:     "no services",
+			compose:  "volumes: {}",
+			expected: types.ListValueMust(types.StringType, []attr.Value{}),
+		},
+		{
+			name:      "invalid",
+			compose:   "services: [",
+			expected:  types.ListUnknown(types.StringType),
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(tt.compose)}),
+			}
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.ListUnknown(types.StringType)),
+			}
+
+			NewComposeServicesFunction().Run(context.Background(), req, resp)
+
+			assert.Equal(t, tt.expectErr, resp.Error != nil)
+			assert.Equal(t, tt.expected, resp.Result.Value())
+		})
+	}
+}
@@ -0,0 +1,258 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-coolify/internal/api"
+	"terraform-provider-coolify/internal/filter"
+	"terraform-provider-coolify/internal/flatten"
+)
+
+type databaseListResourceModel struct {
+	Filter []filter.BlockModel `tfsdk:"filter"`
+}
+
+// databaseListItem is a database returned by the list endpoint, along with the
+// server, project and environment it is deployed to.
+type databaseListItem struct {
+	Identity    databaseIdentityModel
+	Name        types.String
+	Description types.String
+	Image       types.String
+	IsPublic    types.Bool
+}
+
+var _ filter.FilterableStructModel = databaseListItem{}
+
+var databasesFilterNames = []string{"uuid", "name", "description", "image", "is_public", "server_uuid", "project_uuid", "environment_name"}
+
+func (m databaseListItem) FilterAttributes() map[string]attr.Value {
+	return map[string]attr.Value{
+		"description":      m.Description,
+		"environment_name": m.Identity.EnvironmentName,
+		"image":            m.Image,
+		"is_public":        m.IsPublic,
+		"name":             m.Name,
+		"project_uuid":     m.Identity.ProjectUuid,
+		"server_uuid":      m.Identity.ServerUuid,
+		"uuid":             m.Identity.Uuid,
+	}
+}
+
+func databaseListResourceSchema(databaseName string) listschema.Schema {
+	return listschema.Schema{
+		Description: fmt.Sprintf("List Coolify %s databases.", databaseName),
+		Blocks: map[string]listschema.Block{
+			"filter": filter.CreateListResourceFilter(databasesFilterNames),
+		},
+	}
+}
+
+// listDatabases lists the databases of the given type. The list endpoint does
+// not return where a database is deployed, so the servers and environments
+// are looked up separately.
+func listDatabases(
+	ctx context.Context,
+	client *api.ClientWithResponses,
+	diags *diag.Diagnostics,
+	databaseType string,
+) []databaseListItem {
+	listResp, err := client.ListDatabasesWithResponse(ctx)
+	if err != nil {
+		diags.AddError("Error reading databases", err.Error())
+		return nil
+	}
+
+	if listResp.StatusCode() != http.StatusOK {
+		diags.AddError(
+			"Unexpected HTTP status code reading databases",
+			fmt.Sprintf("Received %s for databases. Details: %s", listResp.Status(), listResp.Body),
+		)
+		return nil
+	}
+
+	// Fields of the database model that are not part of the API spec
+	type listedDatabase struct {
+		api.DatabaseCommon
+		EnvironmentId *int `json:"environment_id"`
+	}
+
+	var databases []listedDatabase
+	for _, database := range *listResp.JSON200 {
+		var listed listedDatabase
+		raw, err := database.MarshalJSON()
+		if err == nil {
+			err = json.Unmarshal(raw, &listed)
+		}
+		if err != nil {
+			diags.AddError("Error decoding database", err.Error())
+			return nil
+		}
+
+		if listed.DatabaseType == databaseType {
+			databases = append(databases, listed)
+		}
+	}
+	if len(databases) == 0 {
+		return nil
+	}
+
+	serverUuids, ok := serverUuidsByResource(ctx, client, diags)
+	if !ok {
+		return nil
+	}
+	environments, ok := environmentsById(ctx, client, diags)
+	if !ok {
+		return nil
+	}
+
+	items := make([]databaseListItem, 0, len(databases))
+	for _, database := range databases {
+		item := databaseListItem{
+			Identity: databaseIdentityModel{
+				ServerUuid:      types.StringNull(),
+				ProjectUuid:     types.StringNull(),
+				EnvironmentName: types.StringNull(),
+				Uuid:            types.StringValue(database.Uuid),
+			},
+			Name:        flatten.String(database.Name),
+			Description: flatten.String(database.Description),
+			Image:       flatten.String(database.Image),
+			IsPublic:    flatten.Bool(database.IsPublic),
+		}
+		if serverUuid, ok := serverUuids[database.Uuid]; ok {
+			item.Identity.ServerUuid = types.StringValue(serverUuid)
+		}
+		if database.EnvironmentId != nil {
+			if environment, ok := environments[*database.EnvironmentId]; ok {
+				item.Identity.ProjectUuid = environment.ProjectUuid
+				item.Identity.EnvironmentName = environment.EnvironmentName
+			}
+		}
+		items = append(items, item)
+	}
+
+	return items
+}
+
+// serverUuidsByResource maps the UUID of every resource to the UUID of the
+// server it is deployed to.
+func serverUuidsByResource(
+	ctx context.Context,
+	client *api.ClientWithResponses,
+	diags *diag.Diagnostics,
+) (map[string]string, bool) {
+	serversResp, err := client.ListServersWithResponse(ctx)
+	if err != nil {
+		diags.AddError("Error reading servers", err.Error())
+		return nil, false
+	}
+
+	if serversResp.StatusCode() != http.StatusOK {
+		diags.AddError(
+			"Unexpected HTTP status code reading servers",
+			fmt.Sprintf("Received %s for servers. Details: %s", serversResp.Status(), serversResp.Body),
+		)
+		return nil, false
+	}
+
+	serverUuids := map[string]string{}
+	for _, server := range *serversResp.JSON200 {
+		if server.Uuid == nil {
+			continue
+		}
+
+		resourcesResp, err := client.GetResourcesByServerUuidWithResponse(ctx, *server.Uuid)
+		if err != nil {
+			diags.AddError(fmt.Sprintf("Error reading server resources: uuid=%s", *server.Uuid), err.Error())
+			return nil, false
+		}
+
+		if resourcesResp.StatusCode() != http.StatusOK {
+			diags.AddError(
+				"Unexpected HTTP status code reading server resources",
+				fmt.Sprintf("Received %s for server resources: uuid=%s. Details: %s", resourcesResp.Status(), *server.Uuid, resourcesResp.Body),
+			)
+			return nil, false
+		}
+
+		for _, resource := range *resourcesResp.JSON200 {
+			if resource.Uuid != nil {
+				serverUuids[*resource.Uuid] = *server.Uuid
+			}
+		}
+	}
+
+	return serverUuids, true
+}
+
+type environmentLocation struct {
+	ProjectUuid     types.String
+	EnvironmentName types.String
+}
+
+// environmentsById maps the ID of every environment to its project UUID and
+// name, as resources only reference their environment by ID.
+func environmentsById(
+	ctx context.Context,
+	client *api.ClientWithResponses,
+	diags *diag.Diagnostics,
+) (map[int]environmentLocation, bool) {
+	projectsResp, err := client.ListProjectsWithResponse(ctx)
+	if err != nil {
+		diags.AddError("Error reading projects", err.Error())
+		return nil, false
+	}
+
+	if projectsResp.StatusCode() != http.StatusOK {
+		diags.AddError(
+			"Unexpected HTTP status code reading projects",
+			fmt.Sprintf("Received %s for projects. Details: %s", projectsResp.Status(), projectsResp.Body),
+		)
+		return nil, false
+	}
+
+	environments := map[int]environmentLocation{}
+	for _, project := range *projectsResp.JSON200 {
+		projectEnvironments := project.Environments
+		if projectEnvironments == nil && project.Uuid != nil {
+			// Environments are not always included when listing projects
+			projectResp, err := client.GetProjectByUuidWithResponse(ctx, *project.Uuid)
+			if err != nil {
+				diags.AddError(fmt.Sprintf("Error reading project: uuid=%s", *project.Uuid), err.Error())
+				return nil, false
+			}
+
+			if projectResp.StatusCode() != http.StatusOK {
+				diags.AddError(
+					"Unexpected HTTP status code reading project",
+					fmt.Sprintf("Received %s for project: uuid=%s. Details: %s", projectResp.Status(), *project.Uuid, projectResp.Body),
+				)
+				return nil, false
+			}
+			projectEnvironments = projectResp.JSON200.Environments
+		}
+		if projectEnvironments == nil {
+			continue
+		}
+
+		for _, environment := range *projectEnvironments {
+			if environment.Id != nil {
+				environments[*environment.Id] = environmentLocation{
+					ProjectUuid:     flatten.String(project.Uuid),
+					EnvironmentName: flatten.String(environment.Name),
+				}
+			}
+		}
+	}
+
+	return environments, true
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"terraform-provider-coolify/internal/api"
+)
+
+func TestListDatabases(t *testing.T) {
+	responses := map[string]string{
+		"/databases": `[
+			{"uuid": "pg1", "database_type": "standalone-postgresql", "name": "pg", "environment_id": 2},
+			{"uuid": "my1", "database_type": "standalone-mysql", "name": "mysql", "environment_id": 2},
+			{"uuid": "pg2", "database_type": "standalone-postgresql", "name": "orphan", "environment_id": 99}
+		]`,
+		"/servers":                   `[{"uuid": "server1"}]`,
+		"/servers/server1/resources": `[{"uuid": "pg1", "type": "standalone-postgresql"}, {"uuid": "my1", "type": "standalone-mysql"}]`,
+		"/projects":                  `[{"uuid": "project1"}]`,
+		"/projects/project1":         `{"uuid": "project1", "environments": [{"id": 2, "name": "production"}]}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client, err := api.NewAPIClient("test", server.URL, "1|token", api.RetryConfig{MaxAttempts: 1, MinWait: 1, MaxWait: 1}, api.HTTPConfig{})
+	require.NoError(t, err)
+
+	var diags diag.Diagnostics
+	databases := listDatabases(context.Background(), client, &diags, "standalone-postgresql")
+	require.False(t, diags.HasError(), diags)
+
+	assert.Equal(t, []databaseListItem{
+		{
+			Identity: databaseIdentityModel{
+				ServerUuid:      types.StringValue("server1"),
+				ProjectUuid:     types.StringValue("project1"),
+				EnvironmentName: types.StringValue("production"),
+				Uuid:            types.StringValue("pg1"),
+			},
+			Name:        types.StringValue("pg"),
+			Description: types.StringNull(),
+			Image:       types.StringNull(),
+			IsPublic:    types.BoolNull(),
+		},
+		{
+			Identity: databaseIdentityModel{
+				ServerUuid:      types.StringNull(),
+				ProjectUuid:     types.StringNull(),
+				EnvironmentName: types.StringNull(),
+				Uuid:            types.StringValue("pg2"),
+			},
+			Name:        types.StringValue("orphan"),
+			Description: types.StringNull(),
+			Image:       types.StringNull(),
+			IsPublic:    types.BoolNull(),
+		},
+	}, databases)
+}
@@ -2,7 +2,11 @@ package service
 
 import (
 	"context"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
@@ -12,7 +16,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"terraform-provider-coolify/internal/api"
+	"terraform-provider-coolify/internal/capability"
 	"terraform-provider-coolify/internal/flatten"
+	sutil "terraform-provider-coolify/internal/service/util"
 )
 
 type commonDatabaseModel struct {
@@ -34,12 +40,94 @@ type commonDatabaseModel struct {
 	ProjectUuid             types.String `tfsdk:"project_uuid"`
 	PublicPort              types.Int64  `tfsdk:"public_port"`
 	ServerUuid              types.String `tfsdk:"server_uuid"`
+	TeamId                  types.Int64  `tfsdk:"team_id"`
 	Uuid                    types.String `tfsdk:"uuid"`
 	InternalDbUrl           types.String `tfsdk:"internal_db_url"`
+	DeletionProtection      types.Bool   `tfsdk:"deletion_protection"`
+
+	DeleteOptions *sutil.DeleteOptionsModel `tfsdk:"delete_options"`
+}
+
+type databaseIdentityModel struct {
+	ServerUuid      types.String `tfsdk:"server_uuid"`
+	ProjectUuid     types.String `tfsdk:"project_uuid"`
+	EnvironmentName types.String `tfsdk:"environment_name"`
+	Uuid            types.String `tfsdk:"uuid"`
+	TeamId          types.Int64  `tfsdk:"team_id"`
+}
+
+func databaseIdentitySchema() identityschema.Schema {
+	return identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"server_uuid": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "UUID of the server.",
+			},
+			"project_uuid": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "UUID of the project.",
+			},
+			"environment_name": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "Name of the environment.",
+			},
+			"uuid": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "UUID of the database.",
+			},
+			"team_id": sutil.TeamIdIdentityAttribute(),
+		},
+	}
+}
+
+// importDatabaseState imports a database either from its resource identity or
+// from an import ID in the format `<server_uuid>/<project_uuid>/<environment_name>/<database_uuid>`.
+func importDatabaseState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var identity databaseIdentityModel
+	if req.ID == "" {
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("team_id"), identity.TeamId)...)
+	} else {
+		ids := strings.Split(req.ID, "/")
+		if len(ids) != 4 && len(ids) != 5 {
+			resp.Diagnostics.AddError(
+				"Invalid import ID",
+				"Import ID should be in the format: <server_uuid>/<project_uuid>/<environment_name>/<database_uuid>, optionally followed by /<team_id>",
+			)
+			return
+		}
+		if len(ids) == 5 {
+			sutil.SetImportTeamId(ctx, ids[4], resp)
+		}
+		identity = databaseIdentityModel{
+			ServerUuid:      types.StringValue(ids[0]),
+			ProjectUuid:     types.StringValue(ids[1]),
+			EnvironmentName: types.StringValue(ids[2]),
+			Uuid:            types.StringValue(ids[3]),
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("server_uuid"), identity.ServerUuid)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_uuid"), identity.ProjectUuid)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment_name"), identity.EnvironmentName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("uuid"), identity.Uuid)...)
+}
+
+func (m commonDatabaseModel) Identity() databaseIdentityModel {
+	return databaseIdentityModel{
+		ServerUuid:      m.ServerUuid,
+		ProjectUuid:     m.ProjectUuid,
+		EnvironmentName: m.EnvironmentName,
+		Uuid:            m.Uuid,
+		TeamId:          m.TeamId,
+	}
 }
 
 func (m commonDatabaseModel) CommonSchema(ctx context.Context) schema.Schema {
-	return schema.Schema{
+	return sutil.MergeResourceSchemas(sutil.DeleteOptionsSchema("database"), schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"description": schema.StringAttribute{
 				Optional:    true,
@@ -53,14 +141,10 @@ func (m commonDatabaseModel) CommonSchema(ctx context.Context) schema.Schema {
 				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
 				Default:       stringdefault.StaticString(""),
 			},
-			"environment_name": schema.StringAttribute{
-				Required:      true,
-				Description:   "Name of the environment",
-				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
-			},
+			"environment_name": sutil.PlacementAttribute("Name of the environment.", true),
 			"environment_uuid": schema.StringAttribute{
 				Optional:      true, // todo: should change this to required and optional environment name
-				Description:   "UUID of the environment. Will replace environment_name in future.",
+				Description:   capability.Describe("UUID of the environment. Will replace environment_name in future.", capability.EnvironmentUuid),
 				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
 			},
 			"image": schema.StringAttribute{
@@ -125,20 +209,13 @@ func (m commonDatabaseModel) CommonSchema(ctx context.Context) schema.Schema {
 				Required:    true,
 				Description: "Name of the database",
 			},
-			"project_uuid": schema.StringAttribute{
-				Required:      true,
-				Description:   "UUID of the project",
-				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
-			},
+			"project_uuid": sutil.PlacementAttribute("UUID of the project.", true),
 			"public_port": schema.Int64Attribute{
 				Optional:    true,
 				Description: "Public port of the database",
 			},
-			"server_uuid": schema.StringAttribute{
-				Required:      true,
-				Description:   "UUID of the server",
-				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
-			},
+			"server_uuid": sutil.PlacementAttribute("UUID of the server.", true),
+			"team_id":     sutil.TeamIdAttribute(),
 			"uuid": schema.StringAttribute{
 				Computed:      true,
 				Description:   "UUID of the database.",
@@ -147,11 +224,11 @@ func (m commonDatabaseModel) CommonSchema(ctx context.Context) schema.Schema {
 			"internal_db_url": schema.StringAttribute{
 				Computed:      true,
 				Sensitive:     true,
-				Description:   "Internal URL of the database.",
+				Description:   "Internal URL of the database. The password is left out when the write-only password attribute is used.",
 				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 			},
 		},
-	}
+	})
 }
 
 func (m commonDatabaseModel) FromAPI(apiModel *api.Database, state commonDatabaseModel) commonDatabaseModel {
@@ -165,6 +242,7 @@ func (m commonDatabaseModel) FromAPI(apiModel *api.Database, state commonDatabas
 		ProjectUuid:             state.ProjectUuid,
 		EnvironmentName:         state.EnvironmentName,
 		EnvironmentUuid:         state.EnvironmentUuid,
+		TeamId:                  state.TeamId,
 		DestinationUuid:         state.DestinationUuid,
 		InstantDeploy:           state.InstantDeploy,
 		InternalDbUrl:           flatten.String(db.InternalDbUrl),
@@ -178,5 +256,15 @@ func (m commonDatabaseModel) FromAPI(apiModel *api.Database, state commonDatabas
 		LimitsMemoryReservation: flatten.String(db.LimitsMemoryReservation),
 		LimitsMemorySwap:        flatten.String(db.LimitsMemorySwap),
 		LimitsMemorySwappiness:  flatten.Int64(db.LimitsMemorySwappiness),
+		DeletionProtection:      sutil.DeletionProtectionFromState(state.DeletionProtection),
+		DeleteOptions:           state.DeleteOptions,
 	}
 }
+
+// passwordAttributes returns the schema for a database password along with its
+// write-only variant (`<name>_wo`) and the `<name>_wo_version` attribute that
+// triggers sending the write-only value on update. Exactly one of the plain or
+// write-only attributes must be configured.
+func passwordAttributes(name string, description string) map[string]schema.Attribute {
+	return writeOnlyAttributes(name, description, "Change this value to update the password.")
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-coolify/internal/api"
+	"terraform-provider-coolify/internal/expand"
+	"terraform-provider-coolify/internal/provider/util"
+)
+
+var (
+	_ action.Action              = &deployAction{}
+	_ action.ActionWithConfigure = &deployAction{}
+)
+
+func NewDeployAction() action.Action {
+	return &deployAction{pollInterval: actionPollInterval}
+}
+
+// deployAction deploys resources by UUID or tag and waits for every queued
+// deployment to finish.
+type deployAction struct {
+	providerData *util.ProviderData
+	pollInterval time.Duration
+}
+
+type deployActionModel struct {
+	Uuid    types.String `tfsdk:"uuid"`
+	Tag     types.String `tfsdk:"tag"`
+	Force   types.Bool   `tfsdk:"force"`
+	Pr      types.Int64  `tfsdk:"pr"`
+	TeamId  types.Int64  `tfsdk:"team_id"`
+	Timeout types.String `tfsdk:"timeout"`
+}
+
+func (a *deployAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_deploy"
+}
+
+func (a *deployAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Deploy Coolify resources by UUID or tag and wait for every deployment to finish. A failed or cancelled deployment is reported as an error.",
+		Attributes: map[string]schema.Attribute{
+			"uuid": schema.StringAttribute{
+				Optional:    true,
+				Description: "UUID of the resource to deploy. A comma separated list is also accepted.",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("tag")),
+				},
+			},
+			"tag": schema.StringAttribute{
+				Optional:    true,
+				Description: "Tag of the resources to deploy. A comma separated list is also accepted.",
+			},
+			"force": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Force a rebuild without cache.",
+			},
+			"pr": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Pull request ID to deploy a preview for. Cannot be used with `tag`.",
+			},
+			"team_id": actionTeamIdAttribute(),
+			"timeout": actionTimeoutAttribute(),
+		},
+	}
+}
+
+func (a *deployAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	util.ProviderDataFromActionConfigureRequest(req, &a.providerData, resp)
+}
+
+func (a *deployAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var config deployActionModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	timeout, ok := actionTimeout(ctx, req.Config, a.providerData.ActionTimeout, &resp.Diagnostics)
+	client := a.providerData.ClientForTeam(config.TeamId, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() || !ok {
+		return
+	}
+
+	if !config.Pr.IsNull() && !config.Tag.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("pr"),
+			"Invalid deploy configuration",
+			"A pull request cannot be deployed by tag, set `uuid` instead.",
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Deploying resources", map[string]interface{}{
+		"uuid": config.Uuid.ValueString(),
+		"tag":  config.Tag.ValueString(),
+	})
+
+	deployResp, err := client.DeployByTagOrUuidWithResponse(ctx, &api.DeployByTagOrUuidParams{
+		Uuid:  expand.String(config.Uuid),
+		Tag:   expand.String(config.Tag),
+		Force: expand.Bool(config.Force),
+		Pr:    expand.Int64(config.Pr),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error deploying resources", err.Error())
+		return
+	}
+
+	if deployResp.StatusCode() != http.StatusOK {
+		resp.Diagnostics.AddError(
+			"Unexpected HTTP status code deploying resources",
+			fmt.Sprintf("Received %s for deploy. Details: %s", deployResp.Status(), deployResp.Body),
+		)
+		return
+	}
+
+	if deployResp.JSON200.Deployments == nil || len(*deployResp.JSON200.Deployments) == 0 {
+		resp.Diagnostics.AddWarning(
+			"No deployments queued",
+			fmt.Sprintf("Coolify did not queue any deployments. Details: %s", deployResp.Body),
+		)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for _, deployment := range *deployResp.JSON200.Deployments {
+		if deployment.Message != nil {
+			sendProgress(resp, "%s", *deployment.Message)
+		}
+		if deployment.DeploymentUuid == nil {
+			continue
+		}
+
+		deploymentUuid := *deployment.DeploymentUuid
+		status, err := waitForDeployment(ctx, client, a.pollInterval, deploymentUuid, func(status string) {
+			sendProgress(resp, "Deployment %s is %s", deploymentUuid, status)
+		})
+		if err != nil {
+			addWaitError(ctx, &resp.Diagnostics, fmt.Sprintf("deployment %s", deploymentUuid), status, err)
+			if ctx.Err() != nil {
+				return
+			}
+		}
+	}
+}
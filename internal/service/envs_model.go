@@ -0,0 +1,424 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+
+	"terraform-provider-coolify/internal/api"
+	"terraform-provider-coolify/internal/dotenv"
+	"terraform-provider-coolify/internal/flatten"
+)
+
+// envsResourceModel is the model of the application and service envs resources.
+type envsResourceModel struct {
+	Uuid      types.String           `tfsdk:"uuid"`
+	Env       []envsResourceEnvModel `tfsdk:"env"`
+	Dotenv    types.String           `tfsdk:"dotenv"`
+	Exclusive types.Bool             `tfsdk:"exclusive"`
+	EnvUuids  types.Map              `tfsdk:"env_uuids"`
+	TeamId    types.Int64            `tfsdk:"team_id"`
+}
+
+// envsResourceEnvModel is an element of the `env` set of the application and
+// service envs resources. Variables are identified by key and preview flag;
+// their UUIDs are kept in the `env_uuids` map of the resource.
+type envsResourceEnvModel struct {
+	IsBuildTime types.Bool   `tfsdk:"is_build_time"`
+	IsLiteral   types.Bool   `tfsdk:"is_literal"`
+	IsMultiline types.Bool   `tfsdk:"is_multiline"`
+	IsPreview   types.Bool   `tfsdk:"is_preview"`
+	IsShownOnce types.Bool   `tfsdk:"is_shown_once"`
+	Key         types.String `tfsdk:"key"`
+	Value       types.String `tfsdk:"value"`
+}
+
+// envKey identifies a variable by its key, suffixed with `:preview` for
+// preview deployments. It is also the key of the `env_uuids` map.
+func envKey(key types.String, isPreview types.Bool) string {
+	if isPreview.ValueBool() {
+		return key.ValueString() + ":preview"
+	}
+	return key.ValueString()
+}
+
+func (m envsResourceEnvModel) envKey() string {
+	return envKey(m.Key, m.IsPreview)
+}
+
+// envUuidsAttribute returns the computed `env_uuids` attribute of the envs
+// resources.
+func envUuidsAttribute() schema.MapAttribute {
+	return schema.MapAttribute{
+		Computed:            true,
+		ElementType:         types.StringType,
+		MarkdownDescription: "UUIDs of the managed environment variables, keyed by `key`, or `<key>:preview` for preview variables. Read back after each apply and used to update and delete the variables without listing them again.",
+	}
+}
+
+// envFlagAttributes copies the generated env attributes, defaulting every flag
+// without a default to false so that plans show the value that will be sent.
+func envFlagAttributes(codegenAttributes map[string]schema.Attribute) map[string]schema.Attribute {
+	attributes := make(map[string]schema.Attribute, len(codegenAttributes))
+	for name, attribute := range codegenAttributes {
+		if boolAttribute, ok := attribute.(schema.BoolAttribute); ok && boolAttribute.Default == nil {
+			boolAttribute.Default = booldefault.StaticBool(false)
+			attribute = boolAttribute
+		}
+		attributes[name] = attribute
+	}
+	return attributes
+}
+
+// envsBlockAttributes returns the attributes of an `env` block, based on the
+// generated env schema. Flags default to false so that set elements are fully
+// known at plan time.
+func envsBlockAttributes(codegenAttributes map[string]schema.Attribute) map[string]schema.Attribute {
+	attributes := envFlagAttributes(codegenAttributes)
+	delete(attributes, "uuid")
+
+	makeResourceAttributeRequired(attributes, "key")
+	makeResourceAttributeRequired(attributes, "value")
+	makeResourceAttributeSensitive(attributes, "value")
+	return attributes
+}
+
+// flattenEnvs converts environment variables returned by the API.
+func flattenEnvs(response *[]api.EnvironmentVariable) []envsResourceEnvModel {
+	envs := make([]envsResourceEnvModel, len(*response))
+	for i, env := range *response {
+		envs[i] = envsResourceEnvModel{
+			IsBuildTime: flatten.Bool(env.IsBuildTime),
+			IsLiteral:   flatten.Bool(env.IsLiteral),
+			IsMultiline: flatten.Bool(env.IsMultiline),
+			IsPreview:   flatten.Bool(env.IsPreview),
+			IsShownOnce: flatten.Bool(env.IsShownOnce),
+			Key:         flatten.String(env.Key),
+			Value:       flatten.String(env.Value),
+		}
+	}
+	return envs
+}
+
+// envUuidsByKey maps the key and preview flag of environment variables returned
+// by the API to their UUIDs.
+func envUuidsByKey(response *[]api.EnvironmentVariable) map[string]string {
+	uuids := make(map[string]string, len(*response))
+	for _, env := range *response {
+		if env.Uuid != nil {
+			uuids[envKey(flatten.String(env.Key), flatten.Bool(env.IsPreview))] = *env.Uuid
+		}
+	}
+	return uuids
+}
+
+// envUuidsValue converts UUIDs keyed by envKey to an `env_uuids` value.
+func envUuidsValue(uuids map[string]string) types.Map {
+	elements := make(map[string]attr.Value, len(uuids))
+	for key, uuid := range uuids {
+		elements[key] = types.StringValue(uuid)
+	}
+	return types.MapValueMust(types.StringType, elements)
+}
+
+// envUuids returns the UUIDs recorded in state, keyed by envKey. It returns
+// false when none were recorded, e.g. for state written by an older version.
+func (m envsResourceModel) envUuids() (map[string]string, bool) {
+	if m.EnvUuids.IsNull() || m.EnvUuids.IsUnknown() {
+		return nil, false
+	}
+
+	uuids := make(map[string]string, len(m.EnvUuids.Elements()))
+	for key, value := range m.EnvUuids.Elements() {
+		if uuid, ok := value.(types.String); ok {
+			uuids[key] = uuid.ValueString()
+		}
+	}
+	return uuids, true
+}
+
+// filterRelevantEnvs returns the API envs matching the given state envs. When
+// exclusive, unmanaged API envs are included so that they show up as drift.
+func filterRelevantEnvs(
+	stateEnvs []envsResourceEnvModel,
+	apiEnvs []envsResourceEnvModel,
+	exclusive bool,
+) []envsResourceEnvModel {
+	apiEnvMap := make(map[string]envsResourceEnvModel)
+	for _, env := range apiEnvs {
+		apiEnvMap[env.envKey()] = env
+	}
+
+	filteredEnvs := []envsResourceEnvModel{}
+	stateKeys := make(map[string]bool)
+	for _, env := range stateEnvs {
+		key := env.envKey()
+		stateKeys[key] = true
+		if apiEnv, exists := apiEnvMap[key]; exists {
+			filteredEnvs = append(filteredEnvs, apiEnv)
+		}
+	}
+
+	if exclusive {
+		for _, env := range apiEnvs {
+			if !stateKeys[env.envKey()] {
+				filteredEnvs = append(filteredEnvs, env)
+			}
+		}
+	}
+
+	return filteredEnvs
+}
+
+// dotenvEnvs parses the `dotenv` content of m. Variables that are also set by
+// a non-preview `env` block are left out, as explicit blocks take precedence.
+func (m envsResourceModel) dotenvEnvs() ([]envsResourceEnvModel, error) {
+	if m.Dotenv.IsNull() || m.Dotenv.IsUnknown() {
+		return nil, nil
+	}
+
+	vars, err := dotenv.Parse(m.Dotenv.ValueString())
+	if err != nil {
+		return nil, err
+	}
+
+	explicit := make(map[string]bool)
+	for _, env := range m.Env {
+		explicit[env.envKey()] = true
+	}
+
+	envs := []envsResourceEnvModel{}
+	for key, value := range vars {
+		env := envsResourceEnvModel{
+			Key:         types.StringValue(key),
+			Value:       types.StringValue(value),
+			IsBuildTime: types.BoolValue(false),
+			IsLiteral:   types.BoolValue(false),
+			IsMultiline: types.BoolValue(strings.Contains(value, "\n")),
+			IsPreview:   types.BoolValue(false),
+			IsShownOnce: types.BoolValue(false),
+		}
+		if !explicit[env.envKey()] {
+			envs = append(envs, env)
+		}
+	}
+	return envs, nil
+}
+
+// managedEnvs returns every variable managed by m, from both the `env` blocks
+// and the `dotenv` content.
+func (m envsResourceModel) managedEnvs(diags *diag.Diagnostics) ([]envsResourceEnvModel, bool) {
+	envs, err := m.dotenvEnvs()
+	if err != nil {
+		diags.AddAttributeError(path.Root("dotenv"), "Invalid dotenv content", err.Error())
+		return nil, false
+	}
+	return append(append([]envsResourceEnvModel{}, m.Env...), envs...), true
+}
+
+// refreshEnvs narrows the envs of data, as read from the API, down to the `env`
+// blocks of prior. Variables loaded from the `dotenv` content of prior are
+// left out; if any of them changed, data.Dotenv holds their actual values so
+// that the drift shows up in the plan.
+func refreshEnvs(data *envsResourceModel, prior envsResourceModel) {
+	fromDotenv, _ := prior.dotenvEnvs()
+	dotenvValues := make(map[string]string, len(fromDotenv))
+	for _, env := range fromDotenv {
+		dotenvValues[env.envKey()] = env.Value.ValueString()
+	}
+
+	apiEnvs := []envsResourceEnvModel{}
+	actualValues := make(map[string]string)
+	managed := make(map[string]bool)
+	drifted := false
+	for _, env := range data.Env {
+		key := env.envKey()
+		if expected, ok := dotenvValues[key]; ok {
+			actualValues[env.Key.ValueString()] = env.Value.ValueString()
+			managed[key] = true
+			drifted = drifted || env.Value.ValueString() != expected
+			continue
+		}
+		apiEnvs = append(apiEnvs, env)
+	}
+
+	data.Env = filterRelevantEnvs(prior.Env, apiEnvs, prior.Exclusive.ValueBool())
+	data.Dotenv = prior.Dotenv
+	if drifted || len(actualValues) != len(dotenvValues) {
+		data.Dotenv = types.StringValue(dotenv.Format(actualValues))
+	}
+
+	if uuids, ok := data.envUuids(); ok {
+		for _, env := range data.Env {
+			managed[env.envKey()] = true
+		}
+		for key := range uuids {
+			if !managed[key] {
+				delete(uuids, key)
+			}
+		}
+		data.EnvUuids = envUuidsValue(uuids)
+	}
+}
+
+// mergeEnvs returns envs along with the others that do not share a key and
+// preview flag with any of them.
+func mergeEnvs(envs []envsResourceEnvModel, others []envsResourceEnvModel) []envsResourceEnvModel {
+	merged := append([]envsResourceEnvModel{}, envs...)
+	keys := make(map[string]bool)
+	for _, env := range envs {
+		keys[env.envKey()] = true
+	}
+	for _, env := range others {
+		if !keys[env.envKey()] {
+			merged = append(merged, env)
+		}
+	}
+	return merged
+}
+
+// MARK: Validators
+
+var _ validator.String = dotenvValidator{}
+
+// dotenvValidator rejects `dotenv` content that cannot be parsed.
+type dotenvValidator struct{}
+
+func (v dotenvValidator) Description(_ context.Context) string {
+	return "Must be valid dotenv content."
+}
+
+func (v dotenvValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v dotenvValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := dotenv.Parse(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid dotenv content", err.Error())
+	}
+}
+
+var _ validator.Set = uniqueEnvKeysValidator{}
+
+// uniqueEnvKeysValidator rejects `env` blocks that share a key and preview flag.
+type uniqueEnvKeysValidator struct{}
+
+func (v uniqueEnvKeysValidator) Description(_ context.Context) string {
+	return "Each combination of key and is_preview must only be set once."
+}
+
+func (v uniqueEnvKeysValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v uniqueEnvKeysValidator) ValidateSet(ctx context.Context, req validator.SetRequest, resp *validator.SetResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	seen := make(map[string]bool)
+	for _, element := range req.ConfigValue.Elements() {
+		object, ok := element.(basetypes.ObjectValue)
+		if !ok || object.IsUnknown() {
+			continue
+		}
+
+		key, keyOk := object.Attributes()["key"].(types.String)
+		if !keyOk || key.IsUnknown() {
+			continue
+		}
+
+		// is_preview may be unset in config, in which case it defaults to false
+		isPreview := types.BoolValue(false)
+		if value, ok := object.Attributes()["is_preview"].(types.Bool); ok {
+			if value.IsUnknown() {
+				continue
+			}
+			isPreview = types.BoolValue(value.ValueBool())
+		}
+
+		id := envKey(key, isPreview)
+		if seen[id] {
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtSetValue(object),
+				"Duplicate environment variable",
+				fmt.Sprintf("The environment variable %q (is_preview = %t) is set more than once.", key.ValueString(), isPreview.ValueBool()),
+			)
+		}
+		seen[id] = true
+	}
+}
+
+// MARK: State upgrade
+
+// envsResourceModelV0 is the prior layout of the envs resources, where `env`
+// was an ordered list including the UUID of each variable.
+type envsResourceModelV0 struct {
+	Uuid      types.String        `tfsdk:"uuid"`
+	Env       []envsResourceEnvV0 `tfsdk:"env"`
+	Exclusive types.Bool          `tfsdk:"exclusive"`
+}
+
+type envsResourceEnvV0 struct {
+	envsResourceEnvModel
+	Uuid types.String `tfsdk:"uuid"`
+}
+
+func envsResourceSchemaV0(codegenAttributes map[string]schema.Attribute) *schema.Schema {
+	envAttributes := make(map[string]schema.Attribute, len(codegenAttributes))
+	for name, attribute := range codegenAttributes {
+		envAttributes[name] = attribute
+	}
+
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"uuid":      schema.StringAttribute{Required: true},
+			"exclusive": schema.BoolAttribute{Optional: true, Computed: true},
+		},
+		Blocks: map[string]schema.Block{
+			"env": schema.ListNestedBlock{
+				NestedObject: schema.NestedBlockObject{Attributes: envAttributes},
+			},
+		},
+	}
+}
+
+// upgradeEnvsV0 moves the per-variable UUIDs of the prior list layout to
+// `env_uuids`.
+func upgradeEnvsV0(prior envsResourceModelV0) envsResourceModel {
+	envs := make([]envsResourceEnvModel, len(prior.Env))
+	uuids := make(map[string]string, len(prior.Env))
+	for i, env := range prior.Env {
+		envs[i] = env.envsResourceEnvModel
+		if !env.Uuid.IsNull() && !env.Uuid.IsUnknown() {
+			uuids[env.envKey()] = env.Uuid.ValueString()
+		}
+		for _, flag := range []*types.Bool{&envs[i].IsBuildTime, &envs[i].IsLiteral, &envs[i].IsMultiline, &envs[i].IsPreview, &envs[i].IsShownOnce} {
+			if flag.IsNull() || flag.IsUnknown() {
+				*flag = types.BoolValue(false)
+			}
+		}
+	}
+
+	return envsResourceModel{
+		Uuid:      prior.Uuid,
+		Env:       envs,
+		Dotenv:    types.StringNull(),
+		Exclusive: types.BoolValue(prior.Exclusive.ValueBool()),
+		EnvUuids:  envUuidsValue(uuids),
+		TeamId:    types.Int64Null(),
+	}
+}
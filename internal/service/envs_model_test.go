@@ -0,0 +1,238 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+
+	"terraform-provider-coolify/internal/api"
+	"terraform-provider-coolify/internal/provider/generated/resource_application_envs"
+)
+
+func testEnv(key string, preview bool) envsResourceEnvModel {
+	return envsResourceEnvModel{
+		Key:         types.StringValue(key),
+		Value:       types.StringValue(key + "-value"),
+		IsBuildTime: types.BoolValue(false),
+		IsLiteral:   types.BoolValue(false),
+		IsMultiline: types.BoolValue(false),
+		IsPreview:   types.BoolValue(preview),
+		IsShownOnce: types.BoolValue(false),
+	}
+}
+
+func TestEnvsBlockAttributes(t *testing.T) {
+	attributes := envsBlockAttributes(resource_application_envs.ApplicationEnvsResourceSchema(context.Background()).Attributes)
+
+	assert.NotContains(t, attributes, "uuid")
+	assert.True(t, attributes["key"].IsRequired())
+	assert.True(t, attributes["value"].IsRequired())
+	assert.True(t, attributes["value"].IsSensitive())
+	assert.NotNil(t, attributes["is_preview"].(schema.BoolAttribute).Default)
+}
+
+func TestEnvFlagAttributes(t *testing.T) {
+	codegenAttributes := resource_application_envs.ApplicationEnvsResourceSchema(context.Background()).Attributes
+	attributes := envFlagAttributes(codegenAttributes)
+
+	for _, name := range []string{"is_build_time", "is_literal", "is_multiline", "is_preview", "is_shown_once"} {
+		assert.NotNil(t, attributes[name].(schema.BoolAttribute).Default, name)
+		assert.Nil(t, codegenAttributes[name].(schema.BoolAttribute).Default, name)
+	}
+	assert.Contains(t, attributes, "uuid")
+}
+
+func TestFilterRelevantEnvs(t *testing.T) {
+	state := []envsResourceEnvModel{testEnv("b", false), testEnv("a", false)}
+	apiEnvs := []envsResourceEnvModel{testEnv("a", false), testEnv("unmanaged", false), testEnv("b", false), testEnv("a", true)}
+
+	assert.Equal(t,
+		[]envsResourceEnvModel{testEnv("b", false), testEnv("a", false)},
+		filterRelevantEnvs(state, apiEnvs, false))
+	assert.Equal(t,
+		[]envsResourceEnvModel{testEnv("b", false), testEnv("a", false), testEnv("unmanaged", false), testEnv("a", true)},
+		filterRelevantEnvs(state, apiEnvs, true))
+	assert.Equal(t, []envsResourceEnvModel{}, filterRelevantEnvs(state, nil, false))
+}
+
+func TestMergeEnvs(t *testing.T) {
+	updated := testEnv("a", false)
+	updated.Value = types.StringValue("updated")
+
+	assert.Equal(t,
+		[]envsResourceEnvModel{updated, testEnv("b", false), testEnv("a", true)},
+		mergeEnvs(
+			[]envsResourceEnvModel{updated, testEnv("b", false)},
+			[]envsResourceEnvModel{testEnv("a", false), testEnv("a", true)},
+		))
+	assert.Equal(t, []envsResourceEnvModel{}, mergeEnvs(nil, nil))
+}
+
+func TestEnvUuidsByKey(t *testing.T) {
+	str := func(s string) *string { return &s }
+	preview := true
+
+	uuids := envUuidsByKey(&[]api.EnvironmentVariable{
+		{Key: str("a"), Uuid: str("a-uuid")},
+		{Key: str("a"), IsPreview: &preview, Uuid: str("a-preview-uuid")},
+		{Key: str("no-uuid")},
+	})
+
+	assert.Equal(t, map[string]string{
+		"a":         "a-uuid",
+		"a:preview": "a-preview-uuid",
+	}, uuids)
+}
+
+func TestEnvsResourceModel_EnvUuids(t *testing.T) {
+	_, ok := envsResourceModel{EnvUuids: types.MapNull(types.StringType)}.envUuids()
+	assert.False(t, ok)
+
+	uuids, ok := envsResourceModel{EnvUuids: envUuidsValue(map[string]string{"a": "a-uuid"})}.envUuids()
+	assert.True(t, ok)
+	assert.Equal(t, map[string]string{"a": "a-uuid"}, uuids)
+}
+
+func TestUniqueEnvKeysValidator(t *testing.T) {
+	attrTypes := map[string]attr.Type{
+		"key":        types.StringType,
+		"value":      types.StringType,
+		"is_preview": types.BoolType,
+	}
+	env := func(key string, isPreview types.Bool) attr.Value {
+		return types.ObjectValueMust(attrTypes, map[string]attr.Value{
+			"key":        types.StringValue(key),
+			"value":      types.StringValue(key + "-" + isPreview.String()),
+			"is_preview": isPreview,
+		})
+	}
+
+	tests := []struct {
+		name      string
+		envs      []attr.Value
+		expectErr bool
+	}{
+		{"unique", []attr.Value{env("a", types.BoolNull()), env("b", types.BoolNull())}, false},
+		{"same key, different preview", []attr.Value{env("a", types.BoolNull()), env("a", types.BoolValue(true))}, false},
+		{"duplicate key", []attr.Value{env("a", types.BoolNull()), env("a", types.BoolValue(false))}, true},
+		{"unknown preview", []attr.Value{env("a", types.BoolNull()), env("a", types.BoolUnknown())}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := validator.SetRequest{
+				Path:        path.Root("env"),
+				ConfigValue: types.SetValueMust(types.ObjectType{AttrTypes: attrTypes}, tt.envs),
+			}
+			resp := &validator.SetResponse{}
+
+			uniqueEnvKeysValidator{}.ValidateSet(context.Background(), req, resp)
+
+			assert.Equal(t, tt.expectErr, resp.Diagnostics.HasError())
+		})
+	}
+}
+
+func TestUpgradeEnvsV0(t *testing.T) {
+	prior := envsResourceModelV0{
+		Uuid: types.StringValue("app-uuid"),
+		Env: []envsResourceEnvV0{
+			{envsResourceEnvModel: testEnv("a", false), Uuid: types.StringValue("a-uuid")},
+			{
+				envsResourceEnvModel: envsResourceEnvModel{
+					Key:         types.StringValue("b"),
+					Value:       types.StringValue("b-value"),
+					IsBuildTime: types.BoolNull(),
+					IsLiteral:   types.BoolNull(),
+					IsMultiline: types.BoolNull(),
+					IsPreview:   types.BoolNull(),
+					IsShownOnce: types.BoolNull(),
+				},
+				Uuid: types.StringValue("b-uuid"),
+			},
+		},
+		Exclusive: types.BoolNull(),
+	}
+
+	assert.Equal(t, envsResourceModel{
+		Uuid:      types.StringValue("app-uuid"),
+		Env:       []envsResourceEnvModel{testEnv("a", false), testEnv("b", false)},
+		Dotenv:    types.StringNull(),
+		Exclusive: types.BoolValue(false),
+		EnvUuids:  envUuidsValue(map[string]string{"a": "a-uuid", "b": "b-uuid"}),
+	}, upgradeEnvsV0(prior))
+}
+
+func TestEnvsResourceModel_ManagedEnvs(t *testing.T) {
+	multiline := testEnv("MULTI", false)
+	multiline.Value = types.StringValue("line1\nline2")
+	multiline.IsMultiline = types.BoolValue(true)
+
+	model := envsResourceModel{
+		Env:    []envsResourceEnvModel{testEnv("EXPLICIT", false), testEnv("PREVIEW", true)},
+		Dotenv: types.StringValue("# comment\nexport EXPLICIT=ignored\nPREVIEW=PREVIEW-value\nMULTI=\"line1\nline2\"\n"),
+	}
+
+	var diags diag.Diagnostics
+	envs, ok := model.managedEnvs(&diags)
+	assert.True(t, ok)
+	assert.False(t, diags.HasError())
+	assert.ElementsMatch(t, []envsResourceEnvModel{
+		testEnv("EXPLICIT", false),
+		testEnv("PREVIEW", true),
+		testEnv("PREVIEW", false),
+		multiline,
+	}, envs)
+
+	model.Dotenv = types.StringValue("INVALID")
+	_, ok = model.managedEnvs(&diags)
+	assert.False(t, ok)
+	assert.True(t, diags.HasError())
+}
+
+func TestRefreshEnvs(t *testing.T) {
+	prior := envsResourceModel{
+		Env:       []envsResourceEnvModel{testEnv("a", false)},
+		Dotenv:    types.StringValue("b=b-value\nc=c-value"),
+		Exclusive: types.BoolValue(true),
+	}
+
+	t.Run("in sync", func(t *testing.T) {
+		data := envsResourceModel{Env: []envsResourceEnvModel{testEnv("a", false), testEnv("b", false), testEnv("c", false), testEnv("d", false)}}
+		refreshEnvs(&data, prior)
+
+		assert.Equal(t, []envsResourceEnvModel{testEnv("a", false), testEnv("d", false)}, data.Env)
+		assert.Equal(t, prior.Dotenv, data.Dotenv)
+	})
+
+	t.Run("drifted", func(t *testing.T) {
+		changed := testEnv("b", false)
+		changed.Value = types.StringValue("changed")
+
+		data := envsResourceModel{Env: []envsResourceEnvModel{testEnv("a", false), changed}}
+		refreshEnvs(&data, prior)
+
+		assert.Equal(t, []envsResourceEnvModel{testEnv("a", false)}, data.Env)
+		assert.Equal(t, types.StringValue("b=\"changed\"\n"), data.Dotenv)
+	})
+
+	t.Run("env uuids", func(t *testing.T) {
+		nonExclusive := prior
+		nonExclusive.Exclusive = types.BoolValue(false)
+
+		data := envsResourceModel{
+			Env:      []envsResourceEnvModel{testEnv("a", false), testEnv("b", false), testEnv("d", false)},
+			EnvUuids: envUuidsValue(map[string]string{"a": "a-uuid", "b": "b-uuid", "d": "d-uuid"}),
+		}
+		refreshEnvs(&data, nonExclusive)
+
+		assert.Equal(t, envUuidsValue(map[string]string{"a": "a-uuid", "b": "b-uuid"}), data.EnvUuids)
+	})
+}
@@ -0,0 +1,354 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-coolify/internal/api"
+	"terraform-provider-coolify/internal/expand"
+	"terraform-provider-coolify/internal/provider/util"
+)
+
+var (
+	_ action.Action              = &lifecycleAction{}
+	_ action.ActionWithConfigure = &lifecycleAction{}
+)
+
+type lifecycleOperation string
+
+const (
+	lifecycleStart   lifecycleOperation = "start"
+	lifecycleStop    lifecycleOperation = "stop"
+	lifecycleRestart lifecycleOperation = "restart"
+)
+
+// targetStatus is the prefix of the status a resource reports once the
+// operation has completed.
+func (o lifecycleOperation) targetStatus() string {
+	if o == lifecycleStop {
+		return "exited"
+	}
+	return "running"
+}
+
+func NewApplicationStartAction() action.Action {
+	return newLifecycleAction("application", lifecycleStart)
+}
+
+func NewApplicationStopAction() action.Action {
+	return newLifecycleAction("application", lifecycleStop)
+}
+
+func NewApplicationRestartAction() action.Action {
+	return newLifecycleAction("application", lifecycleRestart)
+}
+
+func NewDatabaseStartAction() action.Action {
+	return newLifecycleAction("database", lifecycleStart)
+}
+
+func NewDatabaseStopAction() action.Action {
+	return newLifecycleAction("database", lifecycleStop)
+}
+
+func NewDatabaseRestartAction() action.Action {
+	return newLifecycleAction("database", lifecycleRestart)
+}
+
+func NewServiceStartAction() action.Action {
+	return newLifecycleAction("service", lifecycleStart)
+}
+
+func NewServiceStopAction() action.Action {
+	return newLifecycleAction("service", lifecycleStop)
+}
+
+func NewServiceRestartAction() action.Action {
+	return newLifecycleAction("service", lifecycleRestart)
+}
+
+func newLifecycleAction(resourceType string, operation lifecycleOperation) *lifecycleAction {
+	return &lifecycleAction{
+		resourceType:  resourceType,
+		operation:     operation,
+		pollInterval:  actionPollInterval,
+		restartWindow: restartObserveWindow,
+	}
+}
+
+// lifecycleAction starts, stops or restarts an application, database or
+// service, then waits for it to reach the matching status.
+type lifecycleAction struct {
+	providerData  *util.ProviderData
+	resourceType  string
+	operation     lifecycleOperation
+	pollInterval  time.Duration
+	restartWindow time.Duration
+}
+
+// lifecycleResult is the part of a start, stop or restart response shared by
+// all resource types.
+type lifecycleResult struct {
+	statusCode     int
+	status         string
+	body           []byte
+	message        *string
+	deploymentUuid *string
+}
+
+func (a *lifecycleAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_%s_%s", req.ProviderTypeName, a.resourceType, a.operation)
+}
+
+func (a *lifecycleAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	description := fmt.Sprintf(
+		"%s a Coolify %s and wait until it reports a `%s` status.",
+		operationVerb(a.operation), a.resourceType, a.operation.targetStatus(),
+	)
+	if a.resourceType == "application" && a.operation != lifecycleStop {
+		description += " The deployment queued by Coolify is awaited first, and a failed deployment is reported as an error."
+	}
+	if a.operation == lifecycleRestart {
+		restarted := fmt.Sprintf("The %s must first report another status", a.resourceType)
+		if a.resourceType == "application" {
+			restarted = "When no deployment is queued, the application must first report another status"
+		}
+		description += " " + restarted + ", so the status from before the restart is not mistaken for its completion."
+	}
+
+	resp.Schema = schema.Schema{
+		Description: description,
+		Attributes: map[string]schema.Attribute{
+			"uuid": schema.StringAttribute{
+				Required:    true,
+				Description: fmt.Sprintf("UUID of the %s.", a.resourceType),
+			},
+			"team_id": actionTeamIdAttribute(),
+			"timeout": actionTimeoutAttribute(),
+		},
+	}
+
+	if a.resourceType == "application" && a.operation == lifecycleStart {
+		resp.Schema.Attributes["force"] = schema.BoolAttribute{
+			Optional:    true,
+			Description: "Force a rebuild of the application.",
+		}
+		resp.Schema.Attributes["instant_deploy"] = schema.BoolAttribute{
+			Optional:    true,
+			Description: "Deploy instantly, skipping the deployment queue.",
+		}
+	}
+}
+
+func (a *lifecycleAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	util.ProviderDataFromActionConfigureRequest(req, &a.providerData, resp)
+}
+
+func (a *lifecycleAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var uuid types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("uuid"), &uuid)...)
+	timeout, ok := actionTimeout(ctx, req.Config, a.providerData.ActionTimeout, &resp.Diagnostics)
+	client := actionClient(ctx, req.Config, a.providerData, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() || !ok {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Invoking %s %s", a.resourceType, a.operation), map[string]interface{}{
+		"uuid": uuid.ValueString(),
+	})
+
+	result, err := a.call(ctx, req, &resp.Diagnostics, client, uuid.ValueString())
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Error invoking %s %s", a.resourceType, a.operation),
+			err.Error(),
+		)
+		return
+	}
+
+	if result.statusCode != http.StatusOK {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unexpected HTTP status code invoking %s %s", a.resourceType, a.operation),
+			fmt.Sprintf("Received %s for %s: uuid=%s. Details: %s", result.status, a.resourceType, uuid.ValueString(), result.body),
+		)
+		return
+	}
+
+	if result.message != nil {
+		sendProgress(resp, "%s", *result.message)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	deployed := result.deploymentUuid != nil && *result.deploymentUuid != ""
+	if deployed {
+		deploymentUuid := *result.deploymentUuid
+		status, err := waitForDeployment(ctx, client, a.pollInterval, deploymentUuid, func(status string) {
+			sendProgress(resp, "Deployment %s is %s", deploymentUuid, status)
+		})
+		if err != nil {
+			addWaitError(ctx, &resp.Diagnostics, fmt.Sprintf("deployment %s", deploymentUuid), status, err)
+			return
+		}
+	}
+
+	target := a.operation.targetStatus()
+	progress := func(status string) {
+		sendProgress(resp, "%s %s is %s", a.resourceType, uuid.ValueString(), status)
+	}
+
+	var status string
+	if a.operation == lifecycleRestart && !deployed {
+		var observed bool
+		status, observed, err = waitForResourceRestart(ctx, client, a.pollInterval, a.restartWindow, a.resourceType, uuid.ValueString(), target, progress)
+		if err == nil && !observed {
+			resp.Diagnostics.AddWarning(
+				fmt.Sprintf("Unable to observe %s restart", a.resourceType),
+				fmt.Sprintf("%s %s kept reporting %q for %s after the restart was requested. It may have restarted between two status reads, or Coolify may not have acted on the restart yet.", a.resourceType, uuid.ValueString(), status, a.restartWindow),
+			)
+			return
+		}
+	} else {
+		status, err = waitForResourceStatus(ctx, client, a.pollInterval, a.resourceType, uuid.ValueString(), target, progress)
+	}
+	if errors.Is(err, errStatusNotReported) {
+		resp.Diagnostics.AddWarning(
+			fmt.Sprintf("Unable to wait for %s status", a.resourceType),
+			fmt.Sprintf("Coolify did not report a status for %s: uuid=%s, so the action completed without waiting for it to be %s.", a.resourceType, uuid.ValueString(), target),
+		)
+		return
+	}
+	if err != nil {
+		addWaitError(ctx, &resp.Diagnostics, fmt.Sprintf("%s %s to be %s", a.resourceType, uuid.ValueString(), target), status, err)
+	}
+}
+
+// MARK: Helper Functions
+
+func operationVerb(operation lifecycleOperation) string {
+	switch operation {
+	case lifecycleStart:
+		return "Start"
+	case lifecycleStop:
+		return "Stop"
+	default:
+		return "Restart"
+	}
+}
+
+// call sends the start, stop or restart request for the resource.
+func (a *lifecycleAction) call(ctx context.Context, req action.InvokeRequest, diags *diag.Diagnostics, client *api.ClientWithResponses, uuid string) (lifecycleResult, error) {
+	var result lifecycleResult
+
+	switch a.resourceType + "/" + string(a.operation) {
+	case "application/start":
+		var force, instantDeploy types.Bool
+		diags.Append(req.Config.GetAttribute(ctx, path.Root("force"), &force)...)
+		diags.Append(req.Config.GetAttribute(ctx, path.Root("instant_deploy"), &instantDeploy)...)
+		if diags.HasError() {
+			return result, nil
+		}
+
+		resp, err := client.StartApplicationByUuidWithResponse(ctx, uuid, &api.StartApplicationByUuidParams{
+			Force:         expand.Bool(force),
+			InstantDeploy: expand.Bool(instantDeploy),
+		})
+		if err != nil {
+			return result, err
+		}
+		result = lifecycleResult{statusCode: resp.StatusCode(), status: resp.Status(), body: resp.Body}
+		if resp.JSON200 != nil {
+			result.message, result.deploymentUuid = resp.JSON200.Message, resp.JSON200.DeploymentUuid
+		}
+	case "application/stop":
+		resp, err := client.StopApplicationByUuidWithResponse(ctx, uuid)
+		if err != nil {
+			return result, err
+		}
+		result = lifecycleResult{statusCode: resp.StatusCode(), status: resp.Status(), body: resp.Body}
+		if resp.JSON200 != nil {
+			result.message = resp.JSON200.Message
+		}
+	case "application/restart":
+		resp, err := client.RestartApplicationByUuidWithResponse(ctx, uuid)
+		if err != nil {
+			return result, err
+		}
+		result = lifecycleResult{statusCode: resp.StatusCode(), status: resp.Status(), body: resp.Body}
+		if resp.JSON200 != nil {
+			result.message, result.deploymentUuid = resp.JSON200.Message, resp.JSON200.DeploymentUuid
+		}
+	case "database/start":
+		resp, err := client.StartDatabaseByUuidWithResponse(ctx, uuid)
+		if err != nil {
+			return result, err
+		}
+		result = lifecycleResult{statusCode: resp.StatusCode(), status: resp.Status(), body: resp.Body}
+		if resp.JSON200 != nil {
+			result.message = resp.JSON200.Message
+		}
+	case "database/stop":
+		resp, err := client.StopDatabaseByUuidWithResponse(ctx, uuid)
+		if err != nil {
+			return result, err
+		}
+		result = lifecycleResult{statusCode: resp.StatusCode(), status: resp.Status(), body: resp.Body}
+		if resp.JSON200 != nil {
+			result.message = resp.JSON200.Message
+		}
+	case "database/restart":
+		resp, err := client.RestartDatabaseByUuidWithResponse(ctx, uuid)
+		if err != nil {
+			return result, err
+		}
+		result = lifecycleResult{statusCode: resp.StatusCode(), status: resp.Status(), body: resp.Body}
+		if resp.JSON200 != nil {
+			result.message = resp.JSON200.Message
+		}
+	case "service/start":
+		resp, err := client.StartServiceByUuidWithResponse(ctx, uuid)
+		if err != nil {
+			return result, err
+		}
+		result = lifecycleResult{statusCode: resp.StatusCode(), status: resp.Status(), body: resp.Body}
+		if resp.JSON200 != nil {
+			result.message = resp.JSON200.Message
+		}
+	case "service/stop":
+		resp, err := client.StopServiceByUuidWithResponse(ctx, uuid)
+		if err != nil {
+			return result, err
+		}
+		result = lifecycleResult{statusCode: resp.StatusCode(), status: resp.Status(), body: resp.Body}
+		if resp.JSON200 != nil {
+			result.message = resp.JSON200.Message
+		}
+	case "service/restart":
+		resp, err := client.RestartServiceByUuidWithResponse(ctx, uuid)
+		if err != nil {
+			return result, err
+		}
+		result = lifecycleResult{statusCode: resp.StatusCode(), status: resp.Status(), body: resp.Body}
+		if resp.JSON200 != nil {
+			result.message = resp.JSON200.Message
+		}
+	default:
+		return result, fmt.Errorf("unsupported action: %s %s", a.resourceType, a.operation)
+	}
+
+	return result, nil
+}
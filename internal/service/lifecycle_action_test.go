@@ -0,0 +1,41 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+
+	"terraform-provider-coolify/internal/acctest"
+)
+
+func TestAccServiceRestartAction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				action "coolify_service_restart" "test" {
+					config {
+						uuid    = "` + acctest.ServiceUUID + `"
+						timeout = "5m"
+					}
+				}
+
+				resource "terraform_data" "trigger" {
+					lifecycle {
+						action_trigger {
+							events  = [after_create]
+							actions = [action.coolify_service_restart.test]
+						}
+					}
+				}
+				`,
+			},
+		},
+	})
+}
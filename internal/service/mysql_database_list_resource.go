@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+
+	"terraform-provider-coolify/internal/filter"
+	sutil "terraform-provider-coolify/internal/service/util"
+)
+
+var (
+	_ list.ListResource              = &mysqlDatabaseListResource{}
+	_ list.ListResourceWithConfigure = &mysqlDatabaseListResource{}
+)
+
+func NewMySQLDatabaseListResource() list.ListResource {
+	return &mysqlDatabaseListResource{}
+}
+
+// mysqlDatabaseListResource shares its metadata, configuration and reads
+// with the mysql database resource.
+type mysqlDatabaseListResource struct {
+	mysqlDatabaseResource
+}
+
+func (r *mysqlDatabaseListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = databaseListResourceSchema("mysql")
+}
+
+func (r *mysqlDatabaseListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config databaseListResourceModel
+	var diags diag.Diagnostics
+
+	diags.Append(req.Config.Get(ctx, &config)...)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	databases := listDatabases(ctx, r.providerData.Client, &diags, "standalone-mysql")
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = sutil.ListResults(databases, req.Limit, func(database databaseListItem) (list.ListResult, bool) {
+		if !filter.OnStruct(ctx, database, config.Filter) {
+			return list.ListResult{}, false
+		}
+
+		result := req.NewListResult(ctx)
+		result.DisplayName = database.Name.ValueString()
+		sutil.SetIdentity(ctx, result.Identity, &result.Diagnostics, database.Identity)
+
+		if req.IncludeResource {
+			// Values not returned by the API are taken from the identity
+			var state mysqlDatabaseResourceModel
+			state.ServerUuid = database.Identity.ServerUuid
+			state.ProjectUuid = database.Identity.ProjectUuid
+			state.EnvironmentName = database.Identity.EnvironmentName
+
+			if data, ok := r.ReadFromAPI(ctx, &result.Diagnostics, database.Identity.Uuid.ValueString(), state); ok {
+				result.Diagnostics.Append(result.Resource.Set(ctx, &data)...)
+			}
+		}
+
+		return result, true
+	})
+}
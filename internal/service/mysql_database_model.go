@@ -9,11 +9,15 @@ import (
 
 type mysqlDatabaseModel struct {
 	commonDatabaseModel
-	MysqlConf         types.String `tfsdk:"mysql_conf"`
-	MysqlDatabase     types.String `tfsdk:"mysql_database"`
-	MysqlPassword     types.String `tfsdk:"mysql_password"`
-	MysqlRootPassword types.String `tfsdk:"mysql_root_password"`
-	MysqlUser         types.String `tfsdk:"mysql_user"`
+	MysqlConf                  types.String `tfsdk:"mysql_conf"`
+	MysqlDatabase              types.String `tfsdk:"mysql_database"`
+	MysqlPassword              types.String `tfsdk:"mysql_password"`
+	MysqlPasswordWo            types.String `tfsdk:"mysql_password_wo"`
+	MysqlPasswordWoVersion     types.Int64  `tfsdk:"mysql_password_wo_version"`
+	MysqlRootPassword          types.String `tfsdk:"mysql_root_password"`
+	MysqlRootPasswordWo        types.String `tfsdk:"mysql_root_password_wo"`
+	MysqlRootPasswordWoVersion types.Int64  `tfsdk:"mysql_root_password_wo_version"`
+	MysqlUser                  types.String `tfsdk:"mysql_user"`
 }
 
 func (m mysqlDatabaseModel) FromAPI(apiModel *api.Database, state mysqlDatabaseModel) (mysqlDatabaseModel, error) {
@@ -23,12 +27,17 @@ func (m mysqlDatabaseModel) FromAPI(apiModel *api.Database, state mysqlDatabaseM
 		return mysqlDatabaseModel{}, err
 	}
 
+	common := commonDatabaseModel{}.FromAPI(apiModel, state.commonDatabaseModel)
+	common.InternalDbUrl = redactUrlPassword(common.InternalDbUrl, state.MysqlPasswordWoVersion)
+
 	return mysqlDatabaseModel{
-		commonDatabaseModel: commonDatabaseModel{}.FromAPI(apiModel, state.commonDatabaseModel),
-		MysqlConf:           flatten.String(db.MysqlConf),
-		MysqlDatabase:       flatten.String(db.MysqlDatabase),
-		MysqlPassword:       flatten.String(db.MysqlPassword),
-		MysqlRootPassword:   flatten.String(db.MysqlRootPassword),
-		MysqlUser:           flatten.String(db.MysqlUser),
+		commonDatabaseModel:        common,
+		MysqlConf:                  flatten.String(db.MysqlConf),
+		MysqlDatabase:              flatten.String(db.MysqlDatabase),
+		MysqlPassword:              flattenWriteOnlyValue(db.MysqlPassword, state.MysqlPasswordWoVersion),
+		MysqlPasswordWoVersion:     state.MysqlPasswordWoVersion,
+		MysqlRootPassword:          flattenWriteOnlyValue(db.MysqlRootPassword, state.MysqlRootPasswordWoVersion),
+		MysqlRootPasswordWoVersion: state.MysqlRootPasswordWoVersion,
+		MysqlUser:                  flatten.String(db.MysqlUser),
 	}, nil
 }
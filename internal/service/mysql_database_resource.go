@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -14,6 +13,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"terraform-provider-coolify/internal/api"
+	"terraform-provider-coolify/internal/capability"
 	"terraform-provider-coolify/internal/expand"
 	"terraform-provider-coolify/internal/provider/util"
 	sutil "terraform-provider-coolify/internal/service/util"
@@ -23,6 +23,7 @@ var (
 	_ resource.Resource                = &mysqlDatabaseResource{}
 	_ resource.ResourceWithConfigure   = &mysqlDatabaseResource{}
 	_ resource.ResourceWithImportState = &mysqlDatabaseResource{}
+	_ resource.ResourceWithIdentity    = &mysqlDatabaseResource{}
 	_ resource.ResourceWithModifyPlan  = &mysqlDatabaseResource{}
 )
 
@@ -33,7 +34,7 @@ func NewMySQLDatabaseResource() resource.Resource {
 }
 
 type mysqlDatabaseResource struct {
-	client *api.ClientWithResponses
+	providerData *util.ProviderData
 }
 
 func (r *mysqlDatabaseResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -53,16 +54,6 @@ func (r *mysqlDatabaseResource) Schema(ctx context.Context, req resource.SchemaR
 				Required:    true,
 				Description: "MySQL database",
 			},
-			"mysql_root_password": schema.StringAttribute{
-				Required:    true,
-				Sensitive:   true,
-				Description: "MySQL root password",
-			},
-			"mysql_password": schema.StringAttribute{
-				Required:    true,
-				Sensitive:   true,
-				Description: "MySQL password",
-			},
 			"mysql_user": schema.StringAttribute{
 				Required:    true,
 				Description: "MySQL user",
@@ -70,11 +61,18 @@ func (r *mysqlDatabaseResource) Schema(ctx context.Context, req resource.SchemaR
 		},
 	}
 
-	resp.Schema = sutil.MergeResourceSchemas(commonSchema, mysqlSchema)
+	passwordSchema := schema.Schema{
+		Attributes: passwordAttributes("mysql_password", "MySQL password"),
+	}
+	rootPasswordSchema := schema.Schema{
+		Attributes: passwordAttributes("mysql_root_password", "MySQL root password"),
+	}
+
+	resp.Schema = sutil.MergeResourceSchemas(commonSchema, passwordSchema, rootPasswordSchema, mysqlSchema)
 }
 
 func (r *mysqlDatabaseResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
-	util.ProviderDataFromResourceConfigureRequest(req, &r.client, resp)
+	util.ProviderDataFromResourceConfigureRequest(req, &r.providerData, resp)
 }
 
 func (r *mysqlDatabaseResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -89,7 +87,20 @@ func (r *mysqlDatabaseResource) Create(ctx context.Context, req resource.CreateR
 		"name": plan.Name.ValueString(),
 	})
 
-	createResp, err := r.client.CreateDatabaseMysqlWithResponse(ctx, api.CreateDatabaseMysqlJSONRequestBody{
+	password := writeOnlyValueForAPI(ctx, req.Config, &resp.Diagnostics, "mysql_password",
+		plan.MysqlPassword, plan.MysqlPasswordWoVersion, types.Int64Null())
+	rootPassword := writeOnlyValueForAPI(ctx, req.Config, &resp.Diagnostics, "mysql_root_password",
+		plan.MysqlRootPassword, plan.MysqlRootPasswordWoVersion, types.Int64Null())
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.ClientForTeam(plan.TeamId, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createResp, err := client.CreateDatabaseMysqlWithResponse(ctx, api.CreateDatabaseMysqlJSONRequestBody{
 		Description:             plan.Description.ValueStringPointer(),
 		Name:                    plan.Name.ValueStringPointer(),
 		DestinationUuid:         plan.DestinationUuid.ValueStringPointer(),
@@ -107,8 +118,8 @@ func (r *mysqlDatabaseResource) Create(ctx context.Context, req resource.CreateR
 		LimitsMemorySwappiness:  expand.Int64(plan.LimitsMemorySwappiness),
 		MysqlConf:               sutil.Base64EncodeAttr(plan.MysqlConf),
 		MysqlDatabase:           plan.MysqlDatabase.ValueStringPointer(),
-		MysqlPassword:           expand.String(plan.MysqlPassword),
-		MysqlRootPassword:       expand.String(plan.MysqlRootPassword),
+		MysqlPassword:           password,
+		MysqlRootPassword:       rootPassword,
 		MysqlUser:               plan.MysqlUser.ValueStringPointer(),
 		ProjectUuid:             plan.ProjectUuid.ValueString(),
 		PublicPort:              expand.Int64(plan.PublicPort),
@@ -124,6 +135,9 @@ func (r *mysqlDatabaseResource) Create(ctx context.Context, req resource.CreateR
 	}
 
 	if createResp.StatusCode() != http.StatusCreated {
+		if sutil.AddValidationErrors(ctx, &resp.Diagnostics, req.Plan.Schema, nil, "Validation error creating MySQL database", createResp.StatusCode(), createResp.Body) {
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Unexpected HTTP status code creating MySQL database",
 			fmt.Sprintf("Received %s creating MySQL database. Details: %s", createResp.Status(), createResp.Body),
@@ -133,6 +147,7 @@ func (r *mysqlDatabaseResource) Create(ctx context.Context, req resource.CreateR
 
 	data, _ := r.ReadFromAPI(ctx, &resp.Diagnostics, createResp.JSON201.Uuid, plan)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	sutil.SetIdentity(ctx, resp.Identity, &resp.Diagnostics, data.Identity())
 }
 
 func (r *mysqlDatabaseResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -157,6 +172,7 @@ func (r *mysqlDatabaseResource) Read(ctx context.Context, req resource.ReadReque
 		return
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	sutil.SetIdentity(ctx, resp.Identity, &resp.Diagnostics, data.Identity())
 }
 
 func (r *mysqlDatabaseResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -179,7 +195,20 @@ func (r *mysqlDatabaseResource) Update(ctx context.Context, req resource.UpdateR
 		"uuid": uuid,
 	})
 
-	updateResp, err := r.client.UpdateDatabaseByUuidWithResponse(ctx, uuid, api.UpdateDatabaseByUuidJSONRequestBody{
+	password := writeOnlyValueForAPI(ctx, req.Config, &resp.Diagnostics, "mysql_password",
+		plan.MysqlPassword, plan.MysqlPasswordWoVersion, state.MysqlPasswordWoVersion)
+	rootPassword := writeOnlyValueForAPI(ctx, req.Config, &resp.Diagnostics, "mysql_root_password",
+		plan.MysqlRootPassword, plan.MysqlRootPasswordWoVersion, state.MysqlRootPasswordWoVersion)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.ClientForTeam(plan.TeamId, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateResp, err := client.UpdateDatabaseByUuidWithResponse(ctx, uuid, api.UpdateDatabaseByUuidJSONRequestBody{
 		Description:             plan.Description.ValueStringPointer(),
 		Image:                   plan.Image.ValueStringPointer(),
 		IsPublic:                plan.IsPublic.ValueBoolPointer(),
@@ -193,8 +222,8 @@ func (r *mysqlDatabaseResource) Update(ctx context.Context, req resource.UpdateR
 		Name:                    plan.Name.ValueStringPointer(),
 		MysqlConf:               sutil.Base64EncodeAttr(plan.MysqlConf),
 		MysqlDatabase:           plan.MysqlDatabase.ValueStringPointer(),
-		MysqlRootPassword:       expand.String(plan.MysqlRootPassword),
-		MysqlPassword:           expand.String(plan.MysqlPassword),
+		MysqlRootPassword:       rootPassword,
+		MysqlPassword:           password,
 		MysqlUser:               plan.MysqlUser.ValueStringPointer(),
 		PublicPort:              expand.Int64(plan.PublicPort),
 	})
@@ -208,6 +237,9 @@ func (r *mysqlDatabaseResource) Update(ctx context.Context, req resource.UpdateR
 	}
 
 	if updateResp.StatusCode() != http.StatusOK {
+		if sutil.AddValidationErrors(ctx, &resp.Diagnostics, req.Plan.Schema, nil, "Validation error updating MySQL database", updateResp.StatusCode(), updateResp.Body) {
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Unexpected HTTP status code updating MySQL database",
 			fmt.Sprintf("Received %s updating MySQL database: uuid=%s. Details: %s", updateResp.Status(), uuid, updateResp.Body))
@@ -215,7 +247,7 @@ func (r *mysqlDatabaseResource) Update(ctx context.Context, req resource.UpdateR
 	}
 
 	if plan.InstantDeploy.ValueBool() {
-		r.client.RestartDatabaseByUuid(ctx, uuid)
+		client.RestartDatabaseByUuid(ctx, uuid)
 	}
 
 	data, ok := r.ReadFromAPI(ctx, &resp.Diagnostics, uuid, plan)
@@ -224,6 +256,7 @@ func (r *mysqlDatabaseResource) Update(ctx context.Context, req resource.UpdateR
 		return
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	sutil.SetIdentity(ctx, resp.Identity, &resp.Diagnostics, data.Identity())
 }
 
 func (r *mysqlDatabaseResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -236,14 +269,28 @@ func (r *mysqlDatabaseResource) Delete(ctx context.Context, req resource.DeleteR
 		return
 	}
 
+	if !sutil.CheckDeletionProtection(&resp.Diagnostics, state.DeletionProtection, "MySQL database", state.Uuid.ValueString()) {
+		return
+	}
+
+	params := state.DeleteOptions.Params()
 	tflog.Debug(ctx, "Deleting MySQL database", map[string]interface{}{
-		"uuid": state.Uuid.ValueString(),
+		"uuid":                      state.Uuid.ValueString(),
+		"delete_configurations":     *params.DeleteConfigurations,
+		"delete_volumes":            *params.DeleteVolumes,
+		"docker_cleanup":            *params.DockerCleanup,
+		"delete_connected_networks": *params.DeleteConnectedNetworks,
 	})
-	deleteResp, err := r.client.DeleteDatabaseByUuidWithResponse(ctx, state.Uuid.ValueString(), &api.DeleteDatabaseByUuidParams{
-		DeleteConfigurations:    types.BoolValue(true).ValueBoolPointer(),
-		DeleteVolumes:           types.BoolValue(true).ValueBoolPointer(),
-		DockerCleanup:           types.BoolValue(true).ValueBoolPointer(),
-		DeleteConnectedNetworks: types.BoolValue(false).ValueBoolPointer(),
+	client := r.providerData.ClientForTeam(state.TeamId, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteResp, err := client.DeleteDatabaseByUuidWithResponse(ctx, state.Uuid.ValueString(), &api.DeleteDatabaseByUuidParams{
+		DeleteConfigurations:    params.DeleteConfigurations,
+		DeleteVolumes:           params.DeleteVolumes,
+		DockerCleanup:           params.DockerCleanup,
+		DeleteConnectedNetworks: params.DeleteConnectedNetworks,
 	})
 
 	if err != nil {
@@ -254,30 +301,26 @@ func (r *mysqlDatabaseResource) Delete(ctx context.Context, req resource.DeleteR
 	if deleteResp.JSON200 == nil {
 		resp.Diagnostics.AddError(
 			"Unexpected HTTP status code deleting MySQL database",
-			fmt.Sprintf("Received %s deleting MySQL database: %s. Details: %s", deleteResp.Status(), state, deleteResp.Body))
+			fmt.Sprintf("Received %s deleting MySQL database: %s. Details: %s", deleteResp.Status(), state.Uuid.ValueString(), deleteResp.Body))
 		return
 	}
 }
 
-func (r *mysqlDatabaseResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	ids := strings.Split(req.ID, "/")
-	if len(ids) != 4 {
-		resp.Diagnostics.AddError(
-			"Invalid import ID",
-			"Import ID should be in the format: <server_uuid>/<project_uuid>/<environment_name>/<database_uuid>",
-		)
-		return
-	}
-
-	serverUuid, projectUuid, environmentName, uuid := ids[0], ids[1], ids[2], ids[3]
+func (r *mysqlDatabaseResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = databaseIdentitySchema()
+}
 
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("server_uuid"), serverUuid)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_uuid"), projectUuid)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment_name"), environmentName)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("uuid"), uuid)...)
+func (r *mysqlDatabaseResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importDatabaseState(ctx, req, resp)
 }
 
 func (r *mysqlDatabaseResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.providerData != nil {
+		capability.RequireAttribute(ctx, r.providerData.Version, req.Config, path.Root("environment_uuid"), capability.EnvironmentUuid, &resp.Diagnostics)
+		sutil.ApplyPlacementDefaults(ctx, req, resp, r.providerData.Defaults.Placement())
+		r.providerData.CheckPlannedTeam(ctx, req.Plan, &resp.Diagnostics)
+	}
+
 	var plan, state *mysqlDatabaseResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -289,6 +332,7 @@ func (r *mysqlDatabaseResource) ModifyPlan(ctx context.Context, req resource.Mod
 	// If the username, password, or db change, the internal URL will change
 	if !(plan.MysqlUser.Equal(state.MysqlUser) &&
 		plan.MysqlPassword.Equal(state.MysqlPassword) &&
+		plan.MysqlPasswordWoVersion.Equal(state.MysqlPasswordWoVersion) &&
 		plan.MysqlDatabase.Equal(state.MysqlDatabase)) {
 		plan.InternalDbUrl = types.StringUnknown()
 		resp.Plan.Set(ctx, &plan)
@@ -303,7 +347,12 @@ func (r *mysqlDatabaseResource) ReadFromAPI(
 	uuid string,
 	state mysqlDatabaseResourceModel,
 ) (mysqlDatabaseResourceModel, bool) {
-	readResp, err := r.client.GetDatabaseByUuidWithResponse(ctx, uuid)
+	client := r.providerData.ClientForTeam(state.TeamId, diags)
+	if client == nil {
+		return mysqlDatabaseResourceModel{}, false
+	}
+
+	readResp, err := client.GetDatabaseByUuidWithResponse(ctx, uuid)
 	if err != nil {
 		diags.AddError(
 			fmt.Sprintf("Error reading MySQL database: uuid=%s", uuid),
@@ -6,9 +6,12 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
 	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 
 	"terraform-provider-coolify/internal/acctest"
 )
@@ -79,6 +82,46 @@ func TestAccMysqlDatabaseResource(t *testing.T) {
 	})
 }
 
+func TestAccMysqlDatabaseResource_Identity(t *testing.T) {
+	randomName := acctest.GetRandomResourceName("mysql-db")
+	resName := "coolify_mysql_database." + randomName
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			{ // Create and Read testing
+				Config: testAccMysqlDatabaseResourceConfig(randomName, "test_db", "user", "password", "root_password"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentity(resName, map[string]knownvalue.Check{
+						"server_uuid":      knownvalue.StringExact(acctest.ServerUUID),
+						"project_uuid":     knownvalue.StringExact(acctest.ProjectUUID),
+						"environment_name": knownvalue.StringExact(acctest.EnvironmentName),
+						"uuid":             knownvalue.NotNull(),
+						"team_id":          knownvalue.Null(),
+					}),
+					statecheck.ExpectIdentityValueMatchesState(resName, tfjsonpath.New("uuid")),
+				},
+			},
+			{ // ImportState by identity testing
+				ResourceName:    resName,
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+				// instant_deploy is not returned by the API, so it is set by the first apply
+				ExpectNonEmptyPlan: true,
+				ImportPlanChecks: resource.ImportPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resName, plancheck.ResourceActionUpdate),
+						plancheck.ExpectKnownValue(resName, tfjsonpath.New("server_uuid"), knownvalue.StringExact(acctest.ServerUUID)),
+					},
+				},
+			},
+		},
+	})
+}
+
 func testAccMysqlDatabaseResourceConfig(name, db, user, password, rootPassword string) string {
 	return fmt.Sprintf(`
 		resource "coolify_mysql_database" "%[1]s" {
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-coolify/internal/dotenv"
+)
+
+var _ function.Function = &parseDotenvFunction{}
+
+func NewParseDotenvFunction() function.Function {
+	return &parseDotenvFunction{}
+}
+
+type parseDotenvFunction struct{}
+
+func (f *parseDotenvFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_dotenv"
+}
+
+func (f *parseDotenvFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Parse dotenv content into a map",
+		MarkdownDescription: "Parses environment variables in dotenv format, using the same rules as the `dotenv` attribute of the `coolify_application_envs` and `coolify_service_envs` resources. Supports `export` prefixes, comments, and single or double quoted values, which may span multiple lines. When a key is defined more than once, the last definition wins.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "content",
+				MarkdownDescription: "Dotenv content, e.g. the contents of a `.env` file.",
+			},
+		},
+		Return: function.MapReturn{ElementType: types.StringType},
+	}
+}
+
+func (f *parseDotenvFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var content string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &content))
+	if resp.Error != nil {
+		return
+	}
+
+	vars, err := dotenv.Parse(content)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid dotenv content: "+err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, vars))
+}
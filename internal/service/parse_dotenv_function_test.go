@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDotenvFunction(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		expected  attr.Value
+		expectErr bool
+	}{
+		{
+			name:    "valid",
+			content: "export FOO=bar # comment\nMULTI=\"a\nb\"",
+			expected: types.MapValueMust(types.StringType, map[string]attr.Value{
+				"FOO":   types.StringValue("bar"),
+				"MULTI": types.StringValue("a\nb"),
+			}),
+		},
+		{
+			name:     "empty",
+			content:  "",
+			expected: types.MapValueMust(types.StringType, map[string]attr.Value{}),
+		},
+		{
+			name:      "invalid",
+			content:   "FOO",
+			expected:  types.MapUnknown(types.StringType),
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(tt.content)}),
+			}
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.MapUnknown(types.StringType)),
+			}
+
+			NewParseDotenvFunction().Run(context.Background(), req, resp)
+
+			assert.Equal(t, tt.expectErr, resp.Error != nil)
+			assert.Equal(t, tt.expected, resp.Result.Value())
+		})
+	}
+}
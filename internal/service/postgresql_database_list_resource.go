@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+
+	"terraform-provider-coolify/internal/filter"
+	sutil "terraform-provider-coolify/internal/service/util"
+)
+
+var (
+	_ list.ListResource              = &postgresqlDatabaseListResource{}
+	_ list.ListResourceWithConfigure = &postgresqlDatabaseListResource{}
+)
+
+func NewPostgresqlDatabaseListResource() list.ListResource {
+	return &postgresqlDatabaseListResource{}
+}
+
+// postgresqlDatabaseListResource shares its metadata, configuration and reads
+// with the postgresql database resource.
+type postgresqlDatabaseListResource struct {
+	postgresqlDatabaseResource
+}
+
+func (r *postgresqlDatabaseListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = databaseListResourceSchema("postgresql")
+}
+
+func (r *postgresqlDatabaseListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config databaseListResourceModel
+	var diags diag.Diagnostics
+
+	diags.Append(req.Config.Get(ctx, &config)...)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	databases := listDatabases(ctx, r.providerData.Client, &diags, "standalone-postgresql")
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = sutil.ListResults(databases, req.Limit, func(database databaseListItem) (list.ListResult, bool) {
+		if !filter.OnStruct(ctx, database, config.Filter) {
+			return list.ListResult{}, false
+		}
+
+		result := req.NewListResult(ctx)
+		result.DisplayName = database.Name.ValueString()
+		sutil.SetIdentity(ctx, result.Identity, &result.Diagnostics, database.Identity)
+
+		if req.IncludeResource {
+			// Values not returned by the API are taken from the identity
+			var state postgresqlDatabaseResourceModel
+			state.ServerUuid = database.Identity.ServerUuid
+			state.ProjectUuid = database.Identity.ProjectUuid
+			state.EnvironmentName = database.Identity.EnvironmentName
+
+			if data, ok := r.ReadFromAPI(ctx, &result.Diagnostics, database.Identity.Uuid.ValueString(), state); ok {
+				result.Diagnostics.Append(result.Resource.Set(ctx, &data)...)
+			}
+		}
+
+		return result, true
+	})
+}
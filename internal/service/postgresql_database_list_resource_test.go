@@ -0,0 +1,69 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+
+	"terraform-provider-coolify/internal/acctest"
+)
+
+func TestAccPostgresqlDatabaseListResource(t *testing.T) {
+	name := acctest.GetRandomResourceName("pg-list")
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		Steps: []resource.TestStep{
+			{ // Create a database to list
+				Config: `
+				resource "coolify_postgresql_database" "test" {
+					name        = "` + name + `"
+					description = "Terraform acceptance testing"
+
+					server_uuid = "` + acctest.ServerUUID + `"
+					project_uuid = "` + acctest.ProjectUUID + `"
+					environment_name = "` + acctest.EnvironmentName + `"
+
+					image = "postgres:16-alpine"
+					postgres_db = "postgres"
+					postgres_user = "postgres"
+					postgres_password = "password"
+				}
+				`,
+			},
+			{
+				Query: true,
+				Config: `
+				provider "coolify" {}
+
+				list "coolify_postgresql_database" "test" {
+					provider = coolify
+
+					config {
+						filter {
+							name   = "name"
+							values = ["` + name + `"]
+						}
+					}
+				}
+				`,
+				QueryResultChecks: []querycheck.QueryResultCheck{
+					querycheck.ExpectLength("coolify_postgresql_database.test", 1),
+					querycheck.ExpectIdentity("coolify_postgresql_database.test", map[string]knownvalue.Check{
+						"server_uuid":      knownvalue.StringExact(acctest.ServerUUID),
+						"project_uuid":     knownvalue.StringExact(acctest.ProjectUUID),
+						"environment_name": knownvalue.StringExact(acctest.EnvironmentName),
+						"uuid":             knownvalue.NotNull(),
+						"team_id":          knownvalue.Null(),
+					}),
+				},
+			},
+		},
+	})
+}
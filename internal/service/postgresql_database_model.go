@@ -9,12 +9,14 @@ import (
 
 type postgresqlDatabaseModel struct {
 	commonDatabaseModel
-	PostgresConf           types.String `tfsdk:"postgres_conf"`
-	PostgresDb             types.String `tfsdk:"postgres_db"`
-	PostgresHostAuthMethod types.String `tfsdk:"postgres_host_auth_method"`
-	PostgresInitdbArgs     types.String `tfsdk:"postgres_initdb_args"`
-	PostgresPassword       types.String `tfsdk:"postgres_password"`
-	PostgresUser           types.String `tfsdk:"postgres_user"`
+	PostgresConf              types.String `tfsdk:"postgres_conf"`
+	PostgresDb                types.String `tfsdk:"postgres_db"`
+	PostgresHostAuthMethod    types.String `tfsdk:"postgres_host_auth_method"`
+	PostgresInitdbArgs        types.String `tfsdk:"postgres_initdb_args"`
+	PostgresPassword          types.String `tfsdk:"postgres_password"`
+	PostgresPasswordWo        types.String `tfsdk:"postgres_password_wo"`
+	PostgresPasswordWoVersion types.Int64  `tfsdk:"postgres_password_wo_version"`
+	PostgresUser              types.String `tfsdk:"postgres_user"`
 }
 
 func (m postgresqlDatabaseModel) FromAPI(apiModel *api.Database, state postgresqlDatabaseModel) (postgresqlDatabaseModel, error) {
@@ -24,13 +26,17 @@ func (m postgresqlDatabaseModel) FromAPI(apiModel *api.Database, state postgresq
 		return postgresqlDatabaseModel{}, err
 	}
 
+	common := commonDatabaseModel{}.FromAPI(apiModel, state.commonDatabaseModel)
+	common.InternalDbUrl = redactUrlPassword(common.InternalDbUrl, state.PostgresPasswordWoVersion)
+
 	return postgresqlDatabaseModel{
-		commonDatabaseModel:    commonDatabaseModel{}.FromAPI(apiModel, state.commonDatabaseModel),
-		PostgresConf:           flatten.String(db.PostgresConf),
-		PostgresDb:             flatten.String(db.PostgresDb),
-		PostgresHostAuthMethod: flatten.String(db.PostgresHostAuthMethod),
-		PostgresInitdbArgs:     flatten.String(db.PostgresInitdbArgs),
-		PostgresPassword:       flatten.String(db.PostgresPassword),
-		PostgresUser:           flatten.String(db.PostgresUser),
+		commonDatabaseModel:       common,
+		PostgresConf:              flatten.String(db.PostgresConf),
+		PostgresDb:                flatten.String(db.PostgresDb),
+		PostgresHostAuthMethod:    flatten.String(db.PostgresHostAuthMethod),
+		PostgresInitdbArgs:        flatten.String(db.PostgresInitdbArgs),
+		PostgresPassword:          flattenWriteOnlyValue(db.PostgresPassword, state.PostgresPasswordWoVersion),
+		PostgresPasswordWoVersion: state.PostgresPasswordWoVersion,
+		PostgresUser:              flatten.String(db.PostgresUser),
 	}, nil
 }
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -14,6 +13,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"terraform-provider-coolify/internal/api"
+	"terraform-provider-coolify/internal/capability"
 	"terraform-provider-coolify/internal/provider/util"
 	sutil "terraform-provider-coolify/internal/service/util"
 )
@@ -22,6 +22,7 @@ var (
 	_ resource.Resource                = &postgresqlDatabaseResource{}
 	_ resource.ResourceWithConfigure   = &postgresqlDatabaseResource{}
 	_ resource.ResourceWithImportState = &postgresqlDatabaseResource{}
+	_ resource.ResourceWithIdentity    = &postgresqlDatabaseResource{}
 	_ resource.ResourceWithModifyPlan  = &postgresqlDatabaseResource{}
 )
 
@@ -32,7 +33,7 @@ func NewPostgresqlDatabaseResource() resource.Resource {
 }
 
 type postgresqlDatabaseResource struct {
-	client *api.ClientWithResponses
+	providerData *util.ProviderData
 }
 
 func (r *postgresqlDatabaseResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -60,11 +61,6 @@ func (r *postgresqlDatabaseResource) Schema(ctx context.Context, req resource.Sc
 				Optional:    true,
 				Description: "PostgreSQL initdb args",
 			},
-			"postgres_password": schema.StringAttribute{
-				Required:    true,
-				Sensitive:   true,
-				Description: "PostgreSQL password",
-			},
 			"postgres_user": schema.StringAttribute{
 				Required:    true,
 				Description: "PostgreSQL user",
@@ -72,11 +68,15 @@ func (r *postgresqlDatabaseResource) Schema(ctx context.Context, req resource.Sc
 		},
 	}
 
-	resp.Schema = sutil.MergeResourceSchemas(commonSchema, postgresqlSchema)
+	passwordSchema := schema.Schema{
+		Attributes: passwordAttributes("postgres_password", "PostgreSQL password"),
+	}
+
+	resp.Schema = sutil.MergeResourceSchemas(commonSchema, passwordSchema, postgresqlSchema)
 }
 
 func (r *postgresqlDatabaseResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
-	util.ProviderDataFromResourceConfigureRequest(req, &r.client, resp)
+	util.ProviderDataFromResourceConfigureRequest(req, &r.providerData, resp)
 }
 
 func (r *postgresqlDatabaseResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -91,7 +91,18 @@ func (r *postgresqlDatabaseResource) Create(ctx context.Context, req resource.Cr
 		"name": plan.Name.ValueString(),
 	})
 
-	createResp, err := r.client.CreateDatabasePostgresqlWithResponse(ctx, api.CreateDatabasePostgresqlJSONRequestBody{
+	password := writeOnlyValueForAPI(ctx, req.Config, &resp.Diagnostics, "postgres_password",
+		plan.PostgresPassword, plan.PostgresPasswordWoVersion, types.Int64Null())
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.ClientForTeam(plan.TeamId, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createResp, err := client.CreateDatabasePostgresqlWithResponse(ctx, api.CreateDatabasePostgresqlJSONRequestBody{
 		Description:     plan.Description.ValueStringPointer(),
 		Name:            plan.Name.ValueStringPointer(),
 		DestinationUuid: plan.DestinationUuid.ValueStringPointer(),
@@ -123,7 +134,7 @@ func (r *postgresqlDatabaseResource) Create(ctx context.Context, req resource.Cr
 		PostgresDb:             plan.PostgresDb.ValueStringPointer(),
 		PostgresHostAuthMethod: plan.PostgresHostAuthMethod.ValueStringPointer(),
 		PostgresInitdbArgs:     plan.PostgresInitdbArgs.ValueStringPointer(),
-		PostgresPassword:       plan.PostgresPassword.ValueStringPointer(),
+		PostgresPassword:       password,
 		PostgresUser:           plan.PostgresUser.ValueStringPointer(),
 		ProjectUuid:            plan.ProjectUuid.ValueString(),
 		PublicPort: func() *int {
@@ -145,6 +156,9 @@ func (r *postgresqlDatabaseResource) Create(ctx context.Context, req resource.Cr
 	}
 
 	if createResp.StatusCode() != http.StatusCreated {
+		if sutil.AddValidationErrors(ctx, &resp.Diagnostics, req.Plan.Schema, nil, "Validation error creating postgresql database", createResp.StatusCode(), createResp.Body) {
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Unexpected HTTP status code creating postgresql database",
 			fmt.Sprintf("Received %s creating postgresql database. Details: %s", createResp.Status(), createResp.Body),
@@ -154,6 +168,7 @@ func (r *postgresqlDatabaseResource) Create(ctx context.Context, req resource.Cr
 
 	data, _ := r.ReadFromAPI(ctx, &resp.Diagnostics, createResp.JSON201.Uuid, plan)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	sutil.SetIdentity(ctx, resp.Identity, &resp.Diagnostics, data.Identity())
 }
 func (r *postgresqlDatabaseResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var state postgresqlDatabaseResourceModel
@@ -177,6 +192,7 @@ func (r *postgresqlDatabaseResource) Read(ctx context.Context, req resource.Read
 		return
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	sutil.SetIdentity(ctx, resp.Identity, &resp.Diagnostics, data.Identity())
 }
 
 func (r *postgresqlDatabaseResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -205,7 +221,18 @@ func (r *postgresqlDatabaseResource) Update(ctx context.Context, req resource.Up
 		"uuid": uuid,
 	})
 
-	updateResp, err := r.client.UpdateDatabaseByUuidWithResponse(ctx, uuid, api.UpdateDatabaseByUuidJSONRequestBody{
+	password := writeOnlyValueForAPI(ctx, req.Config, &resp.Diagnostics, "postgres_password",
+		plan.PostgresPassword, plan.PostgresPasswordWoVersion, state.PostgresPasswordWoVersion)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.providerData.ClientForTeam(plan.TeamId, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateResp, err := client.UpdateDatabaseByUuidWithResponse(ctx, uuid, api.UpdateDatabaseByUuidJSONRequestBody{
 		Description: plan.Description.ValueStringPointer(),
 		Image:       plan.Image.ValueStringPointer(),
 		IsPublic:    plan.IsPublic.ValueBoolPointer(),
@@ -233,7 +260,7 @@ func (r *postgresqlDatabaseResource) Update(ctx context.Context, req resource.Up
 		PostgresDb:             plan.PostgresDb.ValueStringPointer(),
 		PostgresHostAuthMethod: plan.PostgresHostAuthMethod.ValueStringPointer(),
 		PostgresInitdbArgs:     plan.PostgresInitdbArgs.ValueStringPointer(),
-		PostgresPassword:       plan.PostgresPassword.ValueStringPointer(),
+		PostgresPassword:       password,
 		PostgresUser:           plan.PostgresUser.ValueStringPointer(),
 		PublicPort: func() *int {
 			if plan.PublicPort.IsUnknown() || plan.PublicPort.IsNull() {
@@ -253,6 +280,9 @@ func (r *postgresqlDatabaseResource) Update(ctx context.Context, req resource.Up
 	}
 
 	if updateResp.StatusCode() != http.StatusOK {
+		if sutil.AddValidationErrors(ctx, &resp.Diagnostics, req.Plan.Schema, nil, "Validation error updating postgresql database", updateResp.StatusCode(), updateResp.Body) {
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Unexpected HTTP status code updating postgresql database",
 			fmt.Sprintf("Received %s updating postgresql database: uuid=%s. Details: %s", updateResp.Status(), uuid, updateResp.Body))
@@ -260,7 +290,7 @@ func (r *postgresqlDatabaseResource) Update(ctx context.Context, req resource.Up
 	}
 
 	if plan.InstantDeploy.ValueBool() {
-		r.client.RestartDatabaseByUuid(ctx, uuid)
+		client.RestartDatabaseByUuid(ctx, uuid)
 	}
 
 	data, ok := r.ReadFromAPI(ctx, &resp.Diagnostics, uuid, plan)
@@ -269,6 +299,7 @@ func (r *postgresqlDatabaseResource) Update(ctx context.Context, req resource.Up
 		return
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	sutil.SetIdentity(ctx, resp.Identity, &resp.Diagnostics, data.Identity())
 }
 
 func (r *postgresqlDatabaseResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -281,14 +312,28 @@ func (r *postgresqlDatabaseResource) Delete(ctx context.Context, req resource.De
 		return
 	}
 
+	if !sutil.CheckDeletionProtection(&resp.Diagnostics, state.DeletionProtection, "postgresql database", state.Uuid.ValueString()) {
+		return
+	}
+
+	params := state.DeleteOptions.Params()
 	tflog.Debug(ctx, "Deleting postgresql database", map[string]interface{}{
-		"uuid": state.Uuid.ValueString(),
+		"uuid":                      state.Uuid.ValueString(),
+		"delete_configurations":     *params.DeleteConfigurations,
+		"delete_volumes":            *params.DeleteVolumes,
+		"docker_cleanup":            *params.DockerCleanup,
+		"delete_connected_networks": *params.DeleteConnectedNetworks,
 	})
-	deleteResp, err := r.client.DeleteDatabaseByUuidWithResponse(ctx, state.Uuid.ValueString(), &api.DeleteDatabaseByUuidParams{
-		DeleteConfigurations:    types.BoolValue(true).ValueBoolPointer(),
-		DeleteVolumes:           types.BoolValue(true).ValueBoolPointer(),
-		DockerCleanup:           types.BoolValue(true).ValueBoolPointer(),
-		DeleteConnectedNetworks: types.BoolValue(false).ValueBoolPointer(),
+	client := r.providerData.ClientForTeam(state.TeamId, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteResp, err := client.DeleteDatabaseByUuidWithResponse(ctx, state.Uuid.ValueString(), &api.DeleteDatabaseByUuidParams{
+		DeleteConfigurations:    params.DeleteConfigurations,
+		DeleteVolumes:           params.DeleteVolumes,
+		DockerCleanup:           params.DockerCleanup,
+		DeleteConnectedNetworks: params.DeleteConnectedNetworks,
 	})
 
 	if err != nil {
@@ -299,30 +344,26 @@ func (r *postgresqlDatabaseResource) Delete(ctx context.Context, req resource.De
 	if deleteResp.JSON200 == nil {
 		resp.Diagnostics.AddError(
 			"Unexpected HTTP status code deleting postgresql database",
-			fmt.Sprintf("Received %s deleting postgresql database: %s. Details: %s", deleteResp.Status(), state, deleteResp.Body))
+			fmt.Sprintf("Received %s deleting postgresql database: %s. Details: %s", deleteResp.Status(), state.Uuid.ValueString(), deleteResp.Body))
 		return
 	}
 }
 
-func (r *postgresqlDatabaseResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	ids := strings.Split(req.ID, "/")
-	if len(ids) != 4 {
-		resp.Diagnostics.AddError(
-			"Invalid import ID",
-			"Import ID should be in the format: <server_uuid>/<project_uuid>/<environment_name>/<database_uuid>",
-		)
-		return
-	}
-
-	serverUuid, projectUuid, environmentName, uuid := ids[0], ids[1], ids[2], ids[3]
+func (r *postgresqlDatabaseResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = databaseIdentitySchema()
+}
 
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("server_uuid"), serverUuid)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_uuid"), projectUuid)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment_name"), environmentName)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("uuid"), uuid)...)
+func (r *postgresqlDatabaseResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importDatabaseState(ctx, req, resp)
 }
 
 func (r *postgresqlDatabaseResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.providerData != nil {
+		capability.RequireAttribute(ctx, r.providerData.Version, req.Config, path.Root("environment_uuid"), capability.EnvironmentUuid, &resp.Diagnostics)
+		sutil.ApplyPlacementDefaults(ctx, req, resp, r.providerData.Defaults.Placement())
+		r.providerData.CheckPlannedTeam(ctx, req.Plan, &resp.Diagnostics)
+	}
+
 	var plan, state *postgresqlDatabaseResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -334,6 +375,7 @@ func (r *postgresqlDatabaseResource) ModifyPlan(ctx context.Context, req resourc
 	// If the username, password, or db change, the internal URL will change
 	if !(plan.PostgresUser.Equal(state.PostgresUser) &&
 		plan.PostgresPassword.Equal(state.PostgresPassword) &&
+		plan.PostgresPasswordWoVersion.Equal(state.PostgresPasswordWoVersion) &&
 		plan.PostgresDb.Equal(state.PostgresDb)) {
 		plan.InternalDbUrl = types.StringUnknown()
 	}
@@ -349,7 +391,12 @@ func (r *postgresqlDatabaseResource) ReadFromAPI(
 	uuid string,
 	state postgresqlDatabaseResourceModel,
 ) (postgresqlDatabaseResourceModel, bool) {
-	readResp, err := r.client.GetDatabaseByUuidWithResponse(ctx, uuid)
+	client := r.providerData.ClientForTeam(state.TeamId, diags)
+	if client == nil {
+		return postgresqlDatabaseResourceModel{}, false
+	}
+
+	readResp, err := client.GetDatabaseByUuidWithResponse(ctx, uuid)
 	if err != nil {
 		diags.AddError(
 			fmt.Sprintf("Error reading postgresql database: uuid=%s", uuid),
@@ -3,13 +3,16 @@ package service_test
 import (
 	"fmt"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
 	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 
 	"terraform-provider-coolify/internal/acctest"
 )
@@ -115,3 +118,174 @@ func TestAccPostgresqlDatabaseResource(t *testing.T) {
 		},
 	})
 }
+
+func TestAccPostgresqlDatabaseResource_Identity(t *testing.T) {
+	resName := "coolify_postgresql_database.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			{ // Create and Read testing
+				Config: `
+				resource "coolify_postgresql_database" "test" {
+					name        = "TerraformAccTest"
+					description = "Terraform acceptance testing"
+
+					server_uuid = "` + acctest.ServerUUID + `"
+					project_uuid = "` + acctest.ProjectUUID + `"
+					environment_name = "` + acctest.EnvironmentName + `"
+
+					image = "postgres:16-alpine"
+					postgres_db = "postgres"
+					postgres_user = "postgres"
+					postgres_password = "password"
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentity(resName, map[string]knownvalue.Check{
+						"server_uuid":      knownvalue.StringExact(acctest.ServerUUID),
+						"project_uuid":     knownvalue.StringExact(acctest.ProjectUUID),
+						"environment_name": knownvalue.StringExact(acctest.EnvironmentName),
+						"uuid":             knownvalue.NotNull(),
+						"team_id":          knownvalue.Null(),
+					}),
+					statecheck.ExpectIdentityValueMatchesState(resName, tfjsonpath.New("uuid")),
+				},
+			},
+			{ // ImportState by identity testing
+				ResourceName:    resName,
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+				// instant_deploy is not returned by the API, so it is set by the first apply
+				ExpectNonEmptyPlan: true,
+				ImportPlanChecks: resource.ImportPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resName, plancheck.ResourceActionUpdate),
+						plancheck.ExpectKnownValue(resName, tfjsonpath.New("server_uuid"), knownvalue.StringExact(acctest.ServerUUID)),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAccPostgresqlDatabaseResource_WriteOnlyPassword(t *testing.T) {
+	resName := "coolify_postgresql_database.test"
+	config := func(password string, version int) string {
+		return fmt.Sprintf(`
+		resource "coolify_postgresql_database" "test" {
+			name = "TerraformAccTestWriteOnly"
+
+			server_uuid = "%s"
+			project_uuid = "%s"
+			environment_name = "%s"
+
+			postgres_db = "postgres"
+			postgres_user = "postgres"
+			postgres_password_wo = "%s"
+			postgres_password_wo_version = %d
+		}
+		`, acctest.ServerUUID, acctest.ProjectUUID, acctest.EnvironmentName, password, version)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{ // Create with a write-only password
+				Config: config("password", 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr(resName, "postgres_password"),
+					resource.TestCheckNoResourceAttr(resName, "postgres_password_wo"),
+					resource.TestCheckResourceAttr(resName, "postgres_password_wo_version", "1"),
+					resource.TestCheckResourceAttrSet(resName, "internal_db_url"),
+					resource.TestCheckResourceAttrWith(resName, "internal_db_url", func(value string) error {
+						if strings.Contains(value, "password") {
+							return fmt.Errorf("expected the password to be left out of internal_db_url, got %q", value)
+						}
+						return nil
+					}),
+				),
+			},
+			{ // Changing the value without bumping the version is a no-op
+				Config: config("password-changed", 1),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resName, plancheck.ResourceActionNoop),
+					},
+				},
+			},
+			{ // Bumping the version rotates the password
+				Config: config("password-changed", 2),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resName, plancheck.ResourceActionUpdate),
+						plancheck.ExpectUnknownValue(resName, tfjsonpath.New("internal_db_url")),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr(resName, "postgres_password"),
+					resource.TestCheckResourceAttr(resName, "postgres_password_wo_version", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPostgresqlDatabaseResource_DeletionProtection(t *testing.T) {
+	resName := "coolify_postgresql_database.test"
+	config := func(deletionProtection bool) string {
+		return fmt.Sprintf(`
+		resource "coolify_postgresql_database" "test" {
+			name = "TerraformAccTestDeletionProtection"
+
+			server_uuid = "%s"
+			project_uuid = "%s"
+			environment_name = "%s"
+
+			postgres_db = "postgres"
+			postgres_user = "postgres"
+			postgres_password = "password"
+
+			deletion_protection = %t
+			delete_options {
+				keep_volumes = true
+			}
+		}
+		`, acctest.ServerUUID, acctest.ProjectUUID, acctest.EnvironmentName, deletionProtection)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{ // Create with deletion protection
+				Config: config(true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resName, "deletion_protection", "true"),
+					resource.TestCheckResourceAttr(resName, "delete_options.keep_volumes", "true"),
+				),
+			},
+			{ // Destroy fails while protected
+				Config:      config(true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`Deletion protection enabled`),
+			},
+			{ // Disabling protection is an in-place update, allowing destroy
+				Config: config(false),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resName, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr(resName, "deletion_protection", "false"),
+			},
+		},
+	})
+}
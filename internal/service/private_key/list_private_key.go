@@ -0,0 +1,88 @@
+package private_key
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+
+	"terraform-provider-coolify/internal/api"
+	"terraform-provider-coolify/internal/filter"
+	sutil "terraform-provider-coolify/internal/service/util"
+)
+
+var (
+	_ list.ListResource              = &privateKeyListResource{}
+	_ list.ListResourceWithConfigure = &privateKeyListResource{}
+)
+
+func NewPrivateKeyListResource() list.ListResource {
+	return &privateKeyListResource{}
+}
+
+// privateKeyListResource shares its metadata and configuration with the
+// private key resource.
+type privateKeyListResource struct {
+	privateKeyResource
+}
+
+type privateKeyListResourceModel struct {
+	Filter []filter.BlockModel `tfsdk:"filter"`
+}
+
+func (r *privateKeyListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		Description: "List Coolify private keys.",
+		Blocks: map[string]listschema.Block{
+			"filter": filter.CreateListResourceFilter(privateKeysFilterNames),
+		},
+	}
+}
+
+func (r *privateKeyListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config privateKeyListResourceModel
+	var diags diag.Diagnostics
+
+	diags.Append(req.Config.Get(ctx, &config)...)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	listResp, err := r.providerData.Client.ListPrivateKeysWithResponse(ctx)
+	if err != nil {
+		diags.AddError("Error reading private keys", err.Error())
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	if listResp.StatusCode() != http.StatusOK {
+		diags.AddError(
+			"Unexpected HTTP status code reading private keys",
+			fmt.Sprintf("Received %s for private keys. Details: %s", listResp.Status(), listResp.Body),
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = sutil.ListResults(*listResp.JSON200, req.Limit, func(pk api.PrivateKey) (list.ListResult, bool) {
+		// The list endpoint returns the same model as the resource
+		data := privateKeyResourceModel{}.FromAPI(&pk)
+		if !filter.OnStruct(ctx, data, config.Filter) {
+			return list.ListResult{}, false
+		}
+
+		result := req.NewListResult(ctx)
+		result.DisplayName = data.Name.ValueString()
+		sutil.SetIdentity(ctx, result.Identity, &result.Diagnostics, sutil.UuidIdentityModel{Uuid: data.Uuid})
+
+		if req.IncludeResource {
+			result.Diagnostics.Append(result.Resource.Set(ctx, &data)...)
+		}
+
+		return result, true
+	})
+}
@@ -0,0 +1,40 @@
+package private_key_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+
+	"terraform-provider-coolify/internal/acctest"
+)
+
+func TestAccPrivateKeyListResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Query: true,
+				Config: `
+				provider "coolify" {}
+
+				list "coolify_private_key" "test" {
+					provider = coolify
+				}
+				`,
+				QueryResultChecks: []querycheck.QueryResultCheck{
+					querycheck.ExpectIdentity("coolify_private_key.test", map[string]knownvalue.Check{
+						"uuid":    knownvalue.StringExact(acctest.PrivateKeyUUID),
+						"team_id": knownvalue.Null(),
+					}),
+				},
+			},
+		},
+	})
+}
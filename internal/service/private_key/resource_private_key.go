@@ -20,12 +20,14 @@ import (
 
 	"terraform-provider-coolify/internal/api"
 	"terraform-provider-coolify/internal/provider/util"
+	sutil "terraform-provider-coolify/internal/service/util"
 )
 
 var (
 	_ resource.Resource                = &privateKeyResource{}
 	_ resource.ResourceWithConfigure   = &privateKeyResource{}
 	_ resource.ResourceWithImportState = &privateKeyResource{}
+	_ resource.ResourceWithIdentity    = &privateKeyResource{}
 	_ resource.ResourceWithModifyPlan  = &privateKeyResource{}
 )
 
@@ -34,7 +36,7 @@ func NewPrivateKeyResource() resource.Resource {
 }
 
 type privateKeyResource struct {
-	client *api.ClientWithResponses
+	providerData *util.ProviderData
 }
 
 func (r *privateKeyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -72,8 +74,10 @@ func (r *privateKeyResource) Schema(ctx context.Context, req resource.SchemaRequ
 				MarkdownDescription: "The public key of the private key.",
 			},
 			"team_id": schema.Int64Attribute{
+				Optional:      true,
 				Computed:      true,
-				PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+				Description:   "ID of the team owning the private key, managed with the token set for it in the provider `team_tokens`. Defaults to the team of the provider token. To import a private key of another team, append the team ID to the import ID.",
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown(), int64planmodifier.RequiresReplace()},
 			},
 			"uuid": schema.StringAttribute{
 				Computed:      true,
@@ -97,7 +101,7 @@ func (r *privateKeyResource) Schema(ctx context.Context, req resource.SchemaRequ
 }
 
 func (r *privateKeyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
-	util.ProviderDataFromResourceConfigureRequest(req, &r.client, resp)
+	util.ProviderDataFromResourceConfigureRequest(req, &r.providerData, resp)
 }
 
 func (r *privateKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -111,7 +115,12 @@ func (r *privateKeyResource) Create(ctx context.Context, req resource.CreateRequ
 	tflog.Debug(ctx, "Creating private key", map[string]interface{}{
 		"name": plan.Name.ValueString(),
 	})
-	createResp, err := r.client.CreatePrivateKeyWithResponse(ctx, api.CreatePrivateKeyJSONRequestBody{
+	client := r.providerData.ClientForTeam(plan.TeamId, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createResp, err := client.CreatePrivateKeyWithResponse(ctx, api.CreatePrivateKeyJSONRequestBody{
 		Description: plan.Description.ValueStringPointer(),
 		Name:        plan.Name.ValueStringPointer(),
 		PrivateKey:  plan.PrivateKey.ValueString(),
@@ -126,6 +135,9 @@ func (r *privateKeyResource) Create(ctx context.Context, req resource.CreateRequ
 	}
 
 	if createResp.StatusCode() != http.StatusCreated {
+		if sutil.AddValidationErrors(ctx, &resp.Diagnostics, req.Plan.Schema, nil, "Validation error creating private key", createResp.StatusCode(), createResp.Body) {
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Unexpected HTTP status code creating private key",
 			fmt.Sprintf("Received %s creating private key. Details: %s", createResp.Status(), createResp.Body),
@@ -133,8 +145,9 @@ func (r *privateKeyResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
-	data, _ := r.readFromAPI(ctx, &resp.Diagnostics, *createResp.JSON201.Uuid)
+	data, _ := r.readFromAPI(ctx, &resp.Diagnostics, *createResp.JSON201.Uuid, plan.TeamId)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	sutil.SetIdentity(ctx, resp.Identity, &resp.Diagnostics, sutil.UuidIdentityModel{Uuid: data.Uuid})
 }
 
 func (r *privateKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -153,12 +166,13 @@ func (r *privateKeyResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	data, ok := r.readFromAPI(ctx, &resp.Diagnostics, state.Uuid.ValueString())
+	data, ok := r.readFromAPI(ctx, &resp.Diagnostics, state.Uuid.ValueString(), state.TeamId)
 	if !ok {
 		resp.State.RemoveResource(ctx)
 		return
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	sutil.SetIdentity(ctx, resp.Identity, &resp.Diagnostics, sutil.UuidIdentityModel{Uuid: data.Uuid})
 }
 
 func (r *privateKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -184,7 +198,12 @@ func (r *privateKeyResource) Update(ctx context.Context, req resource.UpdateRequ
 	tflog.Debug(ctx, "Updating private key", map[string]interface{}{
 		"uuid": uuid,
 	})
-	updateResp, err := r.client.UpdatePrivateKeyWithResponse(ctx, uuid, api.UpdatePrivateKeyJSONRequestBody{
+	client := r.providerData.ClientForOwnerTeam(state.TeamId, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateResp, err := client.UpdatePrivateKeyWithResponse(ctx, uuid, api.UpdatePrivateKeyJSONRequestBody{
 		Name:        plan.Name.ValueStringPointer(),
 		Description: plan.Description.ValueStringPointer(),
 		PrivateKey:  plan.PrivateKey.ValueString(),
@@ -199,18 +218,22 @@ func (r *privateKeyResource) Update(ctx context.Context, req resource.UpdateRequ
 	}
 
 	if updateResp.StatusCode() != http.StatusCreated {
+		if sutil.AddValidationErrors(ctx, &resp.Diagnostics, req.Plan.Schema, nil, "Validation error updating private key", updateResp.StatusCode(), updateResp.Body) {
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Unexpected HTTP status code updating private key",
 			fmt.Sprintf("Received %s updating private key: uuid=%s. Details: %s", updateResp.Status(), uuid, updateResp.Body))
 		return
 	}
 
-	data, ok := r.readFromAPI(ctx, &resp.Diagnostics, uuid)
+	data, ok := r.readFromAPI(ctx, &resp.Diagnostics, uuid, state.TeamId)
 	if !ok {
 		resp.State.RemoveResource(ctx)
 		return
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	sutil.SetIdentity(ctx, resp.Identity, &resp.Diagnostics, sutil.UuidIdentityModel{Uuid: data.Uuid})
 }
 
 func (r *privateKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -226,7 +249,12 @@ func (r *privateKeyResource) Delete(ctx context.Context, req resource.DeleteRequ
 	tflog.Debug(ctx, "Deleting private key", map[string]interface{}{
 		"uuid": state.Uuid.ValueString(),
 	})
-	deleteResp, err := r.client.DeletePrivateKeyByUuidWithResponse(ctx, state.Uuid.ValueString())
+	client := r.providerData.ClientForOwnerTeam(state.TeamId, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteResp, err := client.DeletePrivateKeyByUuidWithResponse(ctx, state.Uuid.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete private key, got error: %s", err))
 		return
@@ -240,11 +268,25 @@ func (r *privateKeyResource) Delete(ctx context.Context, req resource.DeleteRequ
 	}
 }
 
+func (r *privateKeyResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	// The team of a private key is read from Coolify rather than configured, so
+	// its identity only carries a `team_id` given to import a key of another team
+	resp.IdentitySchema = sutil.UuidIdentitySchema("private key")
+}
+
 func (r *privateKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("uuid"), req, resp)
+	sutil.ImportUuidState(ctx, req, resp)
 }
 
 func (r *privateKeyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Report a configured team without a token before anything is applied.
+	// The planned team is read from the API when not configured.
+	if r.providerData != nil && !req.Plan.Raw.IsNull() {
+		var teamId types.Int64
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("team_id"), &teamId)...)
+		r.providerData.ClientForTeam(teamId, &resp.Diagnostics)
+	}
+
 	var plan, state *privateKeyResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -266,8 +308,14 @@ func (r *privateKeyResource) readFromAPI(
 	ctx context.Context,
 	diags *diag.Diagnostics,
 	uuid string,
+	teamId types.Int64,
 ) (privateKeyResourceModel, bool) {
-	readResp, err := r.client.GetPrivateKeyByUuidWithResponse(ctx, uuid)
+	client := r.providerData.ClientForOwnerTeam(teamId, diags)
+	if client == nil {
+		return privateKeyResourceModel{}, false
+	}
+
+	readResp, err := client.GetPrivateKeyByUuidWithResponse(ctx, uuid)
 	if err != nil {
 		diags.AddError(
 			fmt.Sprintf("Error reading private key: uuid=%s", uuid),
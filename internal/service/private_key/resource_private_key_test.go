@@ -8,8 +8,10 @@ import (
 	tf_acctest "github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 
 	"terraform-provider-coolify/internal/acctest"
 )
@@ -69,6 +71,37 @@ func TestAccPrivateKeyResource(t *testing.T) {
 	})
 }
 
+func TestAccPrivateKeyResource_Identity(t *testing.T) {
+	randomName := acctest.GetRandomResourceName("pk")
+	resName := "coolify_private_key." + randomName
+
+	_, privateKey, err := tf_acctest.RandSSHKeyPair(t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			{ // Create and Read testing
+				Config: testAccPrivateKeyResourceConfig(randomName, privateKey),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentityValueMatchesState(resName, tfjsonpath.New("uuid")),
+				},
+			},
+			{ // ImportState by identity testing
+				ResourceName:    resName,
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
+
 func testAccPrivateKeyResourceConfig(name, privateKey string) string {
 	return fmt.Sprintf(`
 		resource "coolify_private_key" "%[1]s" {
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-coolify/internal/api"
+	"terraform-provider-coolify/internal/filter"
+	"terraform-provider-coolify/internal/flatten"
+	sutil "terraform-provider-coolify/internal/service/util"
+)
+
+var (
+	_ list.ListResource              = &projectListResource{}
+	_ list.ListResourceWithConfigure = &projectListResource{}
+)
+
+func NewProjectListResource() list.ListResource {
+	return &projectListResource{}
+}
+
+// projectListResource shares its metadata, configuration and reads with the
+// project resource.
+type projectListResource struct {
+	projectResource
+}
+
+type projectListResourceModel struct {
+	Filter []filter.BlockModel `tfsdk:"filter"`
+}
+
+func (r *projectListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		Description: "List Coolify projects.",
+		Blocks: map[string]listschema.Block{
+			"filter": filter.CreateListResourceFilter(projectsFilterNames),
+		},
+	}
+}
+
+func (r *projectListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config projectListResourceModel
+	var diags diag.Diagnostics
+
+	diags.Append(req.Config.Get(ctx, &config)...)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	listResp, err := r.providerData.Client.ListProjectsWithResponse(ctx)
+	if err != nil {
+		diags.AddError("Error reading projects", err.Error())
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	if listResp.StatusCode() != http.StatusOK {
+		diags.AddError(
+			"Unexpected HTTP status code reading projects",
+			fmt.Sprintf("Received %s for projects. Details: %s", listResp.Status(), listResp.Body),
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = sutil.ListResults(*listResp.JSON200, req.Limit, func(project api.Project) (list.ListResult, bool) {
+		uuid, name := flatten.String(project.Uuid), flatten.String(project.Name)
+		attributes := map[string]attr.Value{
+			"description": flatten.String(project.Description),
+			"id":          flatten.Int64(project.Id),
+			"name":        name,
+			"uuid":        uuid,
+		}
+		if !filter.OnAttributes(attributes, config.Filter) {
+			return list.ListResult{}, false
+		}
+
+		result := req.NewListResult(ctx)
+		result.DisplayName = name.ValueString()
+		sutil.SetIdentity(ctx, result.Identity, &result.Diagnostics, sutil.UuidIdentityModel{Uuid: uuid})
+
+		if req.IncludeResource {
+			if data, ok := r.ReadFromAPI(ctx, &result.Diagnostics, uuid.ValueString(), types.Int64Null()); ok {
+				result.Diagnostics.Append(result.Resource.Set(ctx, &data)...)
+			}
+		}
+
+		return result, true
+	})
+}
@@ -0,0 +1,48 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+
+	"terraform-provider-coolify/internal/acctest"
+)
+
+func TestAccProjectListResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Query: true,
+				Config: `
+				provider "coolify" {}
+
+				list "coolify_project" "test" {
+					provider = coolify
+
+					config {
+						filter {
+							name   = "uuid"
+							values = ["` + acctest.ProjectUUID + `"]
+						}
+					}
+				}
+				`,
+				QueryResultChecks: []querycheck.QueryResultCheck{
+					querycheck.ExpectLength("coolify_project.test", 1),
+					querycheck.ExpectIdentity("coolify_project.test", map[string]knownvalue.Check{
+						"uuid":    knownvalue.StringExact(acctest.ProjectUUID),
+						"team_id": knownvalue.Null(),
+					}),
+				},
+			},
+		},
+	})
+}
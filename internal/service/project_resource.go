@@ -7,7 +7,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -18,12 +17,15 @@ import (
 	"terraform-provider-coolify/internal/provider/generated/datasource_project"
 	"terraform-provider-coolify/internal/provider/generated/resource_project"
 	"terraform-provider-coolify/internal/provider/util"
+	sutil "terraform-provider-coolify/internal/service/util"
 )
 
 var (
 	_ resource.Resource                = &projectResource{}
 	_ resource.ResourceWithConfigure   = &projectResource{}
 	_ resource.ResourceWithImportState = &projectResource{}
+	_ resource.ResourceWithIdentity    = &projectResource{}
+	_ resource.ResourceWithModifyPlan  = &projectResource{}
 )
 
 func NewProjectResource() resource.Resource {
@@ -31,7 +33,12 @@ func NewProjectResource() resource.Resource {
 }
 
 type projectResource struct {
-	client *api.ClientWithResponses
+	providerData *util.ProviderData
+}
+
+type projectResourceModel struct {
+	resource_project.ProjectModel
+	TeamId types.Int64 `tfsdk:"team_id"`
 }
 
 func (r *projectResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -53,14 +60,16 @@ func (r *projectResource) Schema(ctx context.Context, req resource.SchemaRequest
 	for _, attr := range validateNonEmptyStrings {
 		makeResourceAttributeNonEmpty(resp.Schema.Attributes, attr)
 	}
+
+	resp.Schema.Attributes["team_id"] = sutil.TeamIdAttribute()
 }
 
 func (r *projectResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
-	util.ProviderDataFromResourceConfigureRequest(req, &r.client, resp)
+	util.ProviderDataFromResourceConfigureRequest(req, &r.providerData, resp)
 }
 
 func (r *projectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var plan resource_project.ProjectModel
+	var plan projectResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -70,7 +79,12 @@ func (r *projectResource) Create(ctx context.Context, req resource.CreateRequest
 	tflog.Debug(ctx, "Creating project", map[string]interface{}{
 		"name": plan.Name.ValueString(),
 	})
-	createResp, err := r.client.CreateProjectWithResponse(ctx, api.CreateProjectJSONRequestBody{
+	client := r.providerData.ClientForTeam(plan.TeamId, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createResp, err := client.CreateProjectWithResponse(ctx, api.CreateProjectJSONRequestBody{
 		Description: plan.Description.ValueStringPointer(),
 		Name:        plan.Name.ValueStringPointer(),
 	})
@@ -84,6 +98,9 @@ func (r *projectResource) Create(ctx context.Context, req resource.CreateRequest
 	}
 
 	if createResp.StatusCode() != http.StatusCreated {
+		if sutil.AddValidationErrors(ctx, &resp.Diagnostics, req.Plan.Schema, nil, "Validation error creating project", createResp.StatusCode(), createResp.Body) {
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Unexpected HTTP status code creating project",
 			fmt.Sprintf("Received %s creating project. Details: %s", createResp.Status(), createResp.Body),
@@ -91,12 +108,13 @@ func (r *projectResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
-	data, _ := r.ReadFromAPI(ctx, &resp.Diagnostics, *createResp.JSON201.Uuid)
+	data, _ := r.ReadFromAPI(ctx, &resp.Diagnostics, *createResp.JSON201.Uuid, plan.TeamId)
 	r.copyMissingAttributes(&plan, &data)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	sutil.SetIdentity(ctx, resp.Identity, &resp.Diagnostics, sutil.UuidIdentityModel{Uuid: data.Uuid, TeamId: data.TeamId})
 }
 func (r *projectResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var state resource_project.ProjectModel
+	var state projectResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -111,18 +129,19 @@ func (r *projectResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	data, ok := r.ReadFromAPI(ctx, &resp.Diagnostics, state.Uuid.ValueString())
+	data, ok := r.ReadFromAPI(ctx, &resp.Diagnostics, state.Uuid.ValueString(), state.TeamId)
 	if !ok {
 		resp.State.RemoveResource(ctx)
 		return
 	}
 	r.copyMissingAttributes(&state, &data)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	sutil.SetIdentity(ctx, resp.Identity, &resp.Diagnostics, sutil.UuidIdentityModel{Uuid: data.Uuid, TeamId: data.TeamId})
 }
 
 func (r *projectResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan resource_project.ProjectModel
-	var state resource_project.ProjectModel
+	var plan projectResourceModel
+	var state projectResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -145,7 +164,12 @@ func (r *projectResource) Update(ctx context.Context, req resource.UpdateRequest
 	tflog.Debug(ctx, "Updating project", map[string]interface{}{
 		"uuid": uuid,
 	})
-	updateResp, err := r.client.UpdateProjectByUuidWithResponse(ctx, uuid, api.UpdateProjectByUuidJSONRequestBody{
+	client := r.providerData.ClientForTeam(plan.TeamId, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateResp, err := client.UpdateProjectByUuidWithResponse(ctx, uuid, api.UpdateProjectByUuidJSONRequestBody{
 		Description: plan.Description.ValueStringPointer(),
 		Name:        plan.Name.ValueStringPointer(),
 	})
@@ -159,23 +183,27 @@ func (r *projectResource) Update(ctx context.Context, req resource.UpdateRequest
 	}
 
 	if updateResp.StatusCode() != http.StatusCreated {
+		if sutil.AddValidationErrors(ctx, &resp.Diagnostics, req.Plan.Schema, nil, "Validation error updating project", updateResp.StatusCode(), updateResp.Body) {
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Unexpected HTTP status code updating project",
 			fmt.Sprintf("Received %s updating project: uuid=%s. Details: %s", updateResp.Status(), uuid, updateResp.Body))
 		return
 	}
 
-	data, ok := r.ReadFromAPI(ctx, &resp.Diagnostics, uuid)
+	data, ok := r.ReadFromAPI(ctx, &resp.Diagnostics, uuid, plan.TeamId)
 	if !ok {
 		resp.State.RemoveResource(ctx)
 		return
 	}
 	r.copyMissingAttributes(&plan, &data)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	sutil.SetIdentity(ctx, resp.Identity, &resp.Diagnostics, sutil.UuidIdentityModel{Uuid: data.Uuid, TeamId: data.TeamId})
 }
 
 func (r *projectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var state resource_project.ProjectModel
+	var state projectResourceModel
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -187,7 +215,12 @@ func (r *projectResource) Delete(ctx context.Context, req resource.DeleteRequest
 	tflog.Debug(ctx, "Deleting project", map[string]interface{}{
 		"uuid": state.Uuid.ValueString(),
 	})
-	deleteResp, err := r.client.DeleteProjectByUuidWithResponse(ctx, state.Uuid.ValueString())
+	client := r.providerData.ClientForTeam(state.TeamId, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteResp, err := client.DeleteProjectByUuidWithResponse(ctx, state.Uuid.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete project, got error: %s", err))
 		return
@@ -201,43 +234,63 @@ func (r *projectResource) Delete(ctx context.Context, req resource.DeleteRequest
 	}
 }
 
+func (r *projectResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = sutil.UuidIdentitySchema("project")
+}
+
 func (r *projectResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("uuid"), req, resp)
+	sutil.ImportUuidState(ctx, req, resp)
+}
+
+func (r *projectResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.providerData != nil {
+		r.providerData.CheckPlannedTeam(ctx, req.Plan, &resp.Diagnostics)
+	}
 }
 
 func (r *projectResource) copyMissingAttributes(
-	plan *resource_project.ProjectModel,
-	data *resource_project.ProjectModel,
+	plan *projectResourceModel,
+	data *projectResourceModel,
 ) {
-
+	// Values that are not returned in API response
+	data.TeamId = plan.TeamId
 }
 
 func (r *projectResource) ReadFromAPI(
 	ctx context.Context,
 	diags *diag.Diagnostics,
 	uuid string,
-) (resource_project.ProjectModel, bool) {
-	readResp, err := r.client.GetProjectByUuidWithResponse(ctx, uuid)
+	teamId types.Int64,
+) (projectResourceModel, bool) {
+	client := r.providerData.ClientForTeam(teamId, diags)
+	if client == nil {
+		return projectResourceModel{}, false
+	}
+
+	readResp, err := client.GetProjectByUuidWithResponse(ctx, uuid)
 	if err != nil {
 		diags.AddError(
 			fmt.Sprintf("Error reading project: uuid=%s", uuid),
 			err.Error(),
 		)
-		return resource_project.ProjectModel{}, false
+		return projectResourceModel{}, false
 	}
 
 	if readResp.StatusCode() == http.StatusNotFound {
-		return resource_project.ProjectModel{}, false
+		return projectResourceModel{}, false
 	}
 
 	if readResp.StatusCode() != http.StatusOK {
 		diags.AddError(
 			"Unexpected HTTP status code reading project",
 			fmt.Sprintf("Received %s for project: uuid=%s. Details: %s", readResp.Status(), uuid, readResp.Body))
-		return resource_project.ProjectModel{}, false
+		return projectResourceModel{}, false
 	}
 
-	return r.ApiToModel(ctx, diags, readResp.JSON200), true
+	return projectResourceModel{
+		ProjectModel: r.ApiToModel(ctx, diags, readResp.JSON200),
+		TeamId:       teamId,
+	}, true
 }
 
 func (r *projectResource) ApiToModel(
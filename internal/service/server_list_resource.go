@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-coolify/internal/api"
+	"terraform-provider-coolify/internal/filter"
+	"terraform-provider-coolify/internal/flatten"
+	sutil "terraform-provider-coolify/internal/service/util"
+)
+
+var (
+	_ list.ListResource              = &serverListResource{}
+	_ list.ListResourceWithConfigure = &serverListResource{}
+)
+
+func NewServerListResource() list.ListResource {
+	return &serverListResource{}
+}
+
+// serverListResource shares its metadata, configuration and reads with the
+// server resource.
+type serverListResource struct {
+	serverResource
+}
+
+type serverListResourceModel struct {
+	Filter []filter.BlockModel `tfsdk:"filter"`
+}
+
+func (r *serverListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		Description: "List Coolify servers.",
+		Blocks: map[string]listschema.Block{
+			"filter": filter.CreateListResourceFilter(serversFilterNames),
+		},
+	}
+}
+
+func (r *serverListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config serverListResourceModel
+	var diags diag.Diagnostics
+
+	diags.Append(req.Config.Get(ctx, &config)...)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	listResp, err := r.providerData.Client.ListServersWithResponse(ctx)
+	if err != nil {
+		diags.AddError("Error reading servers", err.Error())
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	if listResp.StatusCode() != http.StatusOK {
+		diags.AddError(
+			"Unexpected HTTP status code reading servers",
+			fmt.Sprintf("Received %s for servers. Details: %s", listResp.Status(), listResp.Body),
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = sutil.ListResults(*listResp.JSON200, req.Limit, func(server api.Server) (list.ListResult, bool) {
+		// TODO: this should be `id` on root object, upstream spec is wrong
+		var id *int
+		if server.Settings != nil {
+			id = server.Settings.ServerId
+		}
+
+		uuid, name := flatten.String(server.Uuid), flatten.String(server.Name)
+		attributes := map[string]attr.Value{
+			"description": flatten.String(server.Description),
+			"id":          flatten.Int64(id),
+			"ip":          flatten.String(server.Ip),
+			"name":        name,
+			"user":        flatten.String(server.User),
+			"uuid":        uuid,
+		}
+		if !filter.OnAttributes(attributes, config.Filter) {
+			return list.ListResult{}, false
+		}
+
+		result := req.NewListResult(ctx)
+		result.DisplayName = name.ValueString()
+		sutil.SetIdentity(ctx, result.Identity, &result.Diagnostics, sutil.UuidIdentityModel{Uuid: uuid})
+
+		if req.IncludeResource {
+			if data, ok := r.ReadFromAPI(ctx, &result.Diagnostics, uuid.ValueString(), types.Int64Null()); ok {
+				result.Diagnostics.Append(result.Resource.Set(ctx, &data)...)
+			}
+		}
+
+		return result, true
+	})
+}
@@ -7,7 +7,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -16,12 +15,15 @@ import (
 	"terraform-provider-coolify/internal/flatten"
 	"terraform-provider-coolify/internal/provider/generated/resource_server"
 	"terraform-provider-coolify/internal/provider/util"
+	sutil "terraform-provider-coolify/internal/service/util"
 )
 
 var (
 	_ resource.Resource                = &serverResource{}
 	_ resource.ResourceWithConfigure   = &serverResource{}
 	_ resource.ResourceWithImportState = &serverResource{}
+	_ resource.ResourceWithIdentity    = &serverResource{}
+	_ resource.ResourceWithModifyPlan  = &serverResource{}
 )
 
 func NewServerResource() resource.Resource {
@@ -29,7 +31,12 @@ func NewServerResource() resource.Resource {
 }
 
 type serverResource struct {
-	client *api.ClientWithResponses
+	providerData *util.ProviderData
+}
+
+type serverResourceModel struct {
+	resource_server.ServerModel
+	TeamId types.Int64 `tfsdk:"team_id"`
 }
 
 func (r *serverResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -50,14 +57,16 @@ func (r *serverResource) Schema(ctx context.Context, req resource.SchemaRequest,
 	for _, attr := range validateNonEmptyStrings {
 		makeResourceAttributeNonEmpty(resp.Schema.Attributes, attr)
 	}
+
+	resp.Schema.Attributes["team_id"] = sutil.TeamIdAttribute()
 }
 
 func (r *serverResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
-	util.ProviderDataFromResourceConfigureRequest(req, &r.client, resp)
+	util.ProviderDataFromResourceConfigureRequest(req, &r.providerData, resp)
 }
 
 func (r *serverResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var plan resource_server.ServerModel
+	var plan serverResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -67,7 +76,12 @@ func (r *serverResource) Create(ctx context.Context, req resource.CreateRequest,
 	tflog.Debug(ctx, "Creating server", map[string]interface{}{
 		"name": plan.Name.ValueString(),
 	})
-	createResp, err := r.client.CreateServerWithResponse(ctx, api.CreateServerJSONRequestBody{
+	client := r.providerData.ClientForTeam(plan.TeamId, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createResp, err := client.CreateServerWithResponse(ctx, api.CreateServerJSONRequestBody{
 		Description:     plan.Description.ValueStringPointer(),
 		Name:            plan.Name.ValueStringPointer(),
 		InstantValidate: plan.InstantValidate.ValueBoolPointer(),
@@ -93,6 +107,9 @@ func (r *serverResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	if createResp.StatusCode() != http.StatusCreated {
+		if sutil.AddValidationErrors(ctx, &resp.Diagnostics, req.Plan.Schema, nil, "Validation error creating server", createResp.StatusCode(), createResp.Body) {
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Unexpected HTTP status code creating server",
 			fmt.Sprintf("Received %s creating server. Details: %s", createResp.Status(), createResp.Body),
@@ -100,12 +117,13 @@ func (r *serverResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	data, _ := r.ReadFromAPI(ctx, &resp.Diagnostics, *createResp.JSON201.Uuid)
+	data, _ := r.ReadFromAPI(ctx, &resp.Diagnostics, *createResp.JSON201.Uuid, plan.TeamId)
 	r.copyMissingAttributes(&plan, &data)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	sutil.SetIdentity(ctx, resp.Identity, &resp.Diagnostics, sutil.UuidIdentityModel{Uuid: data.Uuid, TeamId: data.TeamId})
 }
 func (r *serverResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var state resource_server.ServerModel
+	var state serverResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -120,18 +138,19 @@ func (r *serverResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	data, ok := r.ReadFromAPI(ctx, &resp.Diagnostics, state.Uuid.ValueString())
+	data, ok := r.ReadFromAPI(ctx, &resp.Diagnostics, state.Uuid.ValueString(), state.TeamId)
 	if !ok {
 		resp.State.RemoveResource(ctx)
 		return
 	}
 	r.copyMissingAttributes(&state, &data)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	sutil.SetIdentity(ctx, resp.Identity, &resp.Diagnostics, sutil.UuidIdentityModel{Uuid: data.Uuid, TeamId: data.TeamId})
 }
 
 func (r *serverResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan resource_server.ServerModel
-	var state resource_server.ServerModel
+	var plan serverResourceModel
+	var state serverResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -154,7 +173,12 @@ func (r *serverResource) Update(ctx context.Context, req resource.UpdateRequest,
 	tflog.Debug(ctx, "Updating server", map[string]interface{}{
 		"uuid": uuid,
 	})
-	updateResp, err := r.client.UpdateServerByUuidWithResponse(ctx, uuid, api.UpdateServerByUuidJSONRequestBody{
+	client := r.providerData.ClientForTeam(plan.TeamId, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateResp, err := client.UpdateServerByUuidWithResponse(ctx, uuid, api.UpdateServerByUuidJSONRequestBody{
 		Description:     plan.Description.ValueStringPointer(),
 		Name:            plan.Name.ValueStringPointer(),
 		InstantValidate: plan.InstantValidate.ValueBoolPointer(),
@@ -185,23 +209,27 @@ func (r *serverResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 
 	if updateResp.StatusCode() != http.StatusCreated {
+		if sutil.AddValidationErrors(ctx, &resp.Diagnostics, req.Plan.Schema, nil, "Validation error updating server", updateResp.StatusCode(), updateResp.Body) {
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Unexpected HTTP status code updating server",
 			fmt.Sprintf("Received %s updating server: uuid=%s. Details: %s", updateResp.Status(), uuid, updateResp.Body))
 		return
 	}
 
-	data, ok := r.ReadFromAPI(ctx, &resp.Diagnostics, uuid)
+	data, ok := r.ReadFromAPI(ctx, &resp.Diagnostics, uuid, plan.TeamId)
 	if !ok {
 		resp.State.RemoveResource(ctx)
 		return
 	}
 	r.copyMissingAttributes(&plan, &data)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	sutil.SetIdentity(ctx, resp.Identity, &resp.Diagnostics, sutil.UuidIdentityModel{Uuid: data.Uuid, TeamId: data.TeamId})
 }
 
 func (r *serverResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var state resource_server.ServerModel
+	var state serverResourceModel
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -213,7 +241,12 @@ func (r *serverResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	tflog.Debug(ctx, "Deleting server", map[string]interface{}{
 		"uuid": state.Uuid.ValueString(),
 	})
-	deleteResp, err := r.client.DeleteServerByUuidWithResponse(ctx, state.Uuid.ValueString())
+	client := r.providerData.ClientForTeam(state.TeamId, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteResp, err := client.DeleteServerByUuidWithResponse(ctx, state.Uuid.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete server, got error: %s", err))
 		return
@@ -227,16 +260,27 @@ func (r *serverResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	}
 }
 
+func (r *serverResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = sutil.UuidIdentitySchema("server")
+}
+
 func (r *serverResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("uuid"), req, resp)
+	sutil.ImportUuidState(ctx, req, resp)
+}
+
+func (r *serverResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.providerData != nil {
+		r.providerData.CheckPlannedTeam(ctx, req.Plan, &resp.Diagnostics)
+	}
 }
 
 func (r *serverResource) copyMissingAttributes(
-	plan *resource_server.ServerModel,
-	data *resource_server.ServerModel,
+	plan *serverResourceModel,
+	data *serverResourceModel,
 ) {
 	// Values that are not returned in API response
 	data.InstantValidate = plan.InstantValidate
+	data.TeamId = plan.TeamId
 	data.PrivateKeyUuid = plan.PrivateKeyUuid
 
 	if plan.PrivateKeyUuid.IsNull() {
@@ -251,28 +295,37 @@ func (r *serverResource) ReadFromAPI(
 	ctx context.Context,
 	diags *diag.Diagnostics,
 	uuid string,
-) (resource_server.ServerModel, bool) {
-	readResp, err := r.client.GetServerByUuidWithResponse(ctx, uuid)
+	teamId types.Int64,
+) (serverResourceModel, bool) {
+	client := r.providerData.ClientForTeam(teamId, diags)
+	if client == nil {
+		return serverResourceModel{}, false
+	}
+
+	readResp, err := client.GetServerByUuidWithResponse(ctx, uuid)
 	if err != nil {
 		diags.AddError(
 			fmt.Sprintf("Error reading server: uuid=%s", uuid),
 			err.Error(),
 		)
-		return resource_server.ServerModel{}, false
+		return serverResourceModel{}, false
 	}
 
 	if readResp.StatusCode() == http.StatusNotFound {
-		return resource_server.ServerModel{}, false
+		return serverResourceModel{}, false
 	}
 
 	if readResp.StatusCode() != http.StatusOK {
 		diags.AddError(
 			"Unexpected HTTP status code reading server",
 			fmt.Sprintf("Received %s for server: uuid=%s. Details: %s", readResp.Status(), uuid, readResp.Body))
-		return resource_server.ServerModel{}, false
+		return serverResourceModel{}, false
 	}
 
-	return r.ApiToModel(ctx, diags, readResp.JSON200), true
+	return serverResourceModel{
+		ServerModel: r.ApiToModel(ctx, diags, readResp.JSON200),
+		TeamId:      teamId,
+	}, true
 }
 
 func (r *serverResource) ApiToModel(
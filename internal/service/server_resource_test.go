@@ -9,8 +9,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
 	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 
 	"terraform-provider-coolify/internal/acctest"
 	"terraform-provider-coolify/internal/service"
@@ -85,6 +87,39 @@ func TestAccServerResource(t *testing.T) {
 	})
 }
 
+func TestAccServerResource_Identity(t *testing.T) {
+	resName := "coolify_server.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			{ // Create and Read testing
+				Config: `
+				resource "coolify_server" "test" {
+					name        = "TerraformAccTest"
+					description = "Terraform acceptance testing"
+					ip = "localhost"
+					port = 22
+					private_key_uuid = "` + acctest.PrivateKeyUUID + `"
+					instant_validate = false
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentityValueMatchesState(resName, tfjsonpath.New("uuid")),
+				},
+			},
+			{ // ImportState by identity testing
+				ResourceName:    resName,
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
+
 func TestServerResourceSchema(t *testing.T) {
 	ctx := context.Background()
 	rs := service.NewServerResource()
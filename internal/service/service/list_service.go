@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+
+	"terraform-provider-coolify/internal/api"
+	"terraform-provider-coolify/internal/filter"
+	"terraform-provider-coolify/internal/flatten"
+	sutil "terraform-provider-coolify/internal/service/util"
+)
+
+var (
+	_ list.ListResource              = &serviceListResource{}
+	_ list.ListResourceWithConfigure = &serviceListResource{}
+)
+
+func NewServiceListResource() list.ListResource {
+	return &serviceListResource{}
+}
+
+// serviceListResource shares its metadata, configuration and reads with the
+// service resource.
+type serviceListResource struct {
+	ServiceResource
+}
+
+type serviceListResourceModel struct {
+	Filter []filter.BlockModel `tfsdk:"filter"`
+}
+
+var servicesFilterNames = []string{"id", "uuid", "name", "description", "service_type"}
+
+func (r *serviceListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		Description: "List Coolify services.",
+		Blocks: map[string]listschema.Block{
+			"filter": filter.CreateListResourceFilter(servicesFilterNames),
+		},
+	}
+}
+
+func (r *serviceListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config serviceListResourceModel
+	var diags diag.Diagnostics
+
+	diags.Append(req.Config.Get(ctx, &config)...)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	listResp, err := r.providerData.Client.ListServicesWithResponse(ctx)
+	if err != nil {
+		diags.AddError("Error reading services", err.Error())
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	if listResp.StatusCode() != http.StatusOK {
+		diags.AddError(
+			"Unexpected HTTP status code reading services",
+			fmt.Sprintf("Received %s for services. Details: %s", listResp.Status(), listResp.Body),
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = sutil.ListResults(*listResp.JSON200, req.Limit, func(service api.Service) (list.ListResult, bool) {
+		uuid, name := flatten.String(service.Uuid), flatten.String(service.Name)
+		attributes := map[string]attr.Value{
+			"description":  flatten.String(service.Description),
+			"id":           flatten.Int64(service.Id),
+			"name":         name,
+			"service_type": flatten.String(service.ServiceType),
+			"uuid":         uuid,
+		}
+		if !filter.OnAttributes(attributes, config.Filter) {
+			return list.ListResult{}, false
+		}
+
+		result := req.NewListResult(ctx)
+		result.DisplayName = name.ValueString()
+		sutil.SetIdentity(ctx, result.Identity, &result.Diagnostics, sutil.UuidIdentityModel{Uuid: uuid})
+
+		if req.IncludeResource {
+			if data, ok := r.ReadFromAPI(ctx, &result.Diagnostics, uuid.ValueString(), ServiceResourceModel{}); ok {
+				result.Diagnostics.Append(result.Resource.Set(ctx, &data)...)
+			}
+		}
+
+		return result, true
+	})
+}
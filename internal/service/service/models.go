@@ -3,6 +3,8 @@ package service
 import (
 	"context"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -11,25 +13,37 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"terraform-provider-coolify/internal/api"
+	"terraform-provider-coolify/internal/capability"
 	"terraform-provider-coolify/internal/flatten"
 	sutil "terraform-provider-coolify/internal/service/util"
 )
 
 type ServiceModel struct {
-	Uuid                   types.String `tfsdk:"uuid"`
-	Name                   types.String `tfsdk:"name"`
-	Description            types.String `tfsdk:"description"`
-	DestinationUuid        types.String `tfsdk:"destination_uuid"`
-	EnvironmentName        types.String `tfsdk:"environment_name"`
-	EnvironmentUuid        types.String `tfsdk:"environment_uuid"`
-	ProjectUuid            types.String `tfsdk:"project_uuid"`
-	ServerUuid             types.String `tfsdk:"server_uuid"`
-	InstantDeploy          types.Bool   `tfsdk:"instant_deploy"`
-	Compose                types.String `tfsdk:"compose"`
+	Uuid            types.String    `tfsdk:"uuid"`
+	Name            types.String    `tfsdk:"name"`
+	Description     types.String    `tfsdk:"description"`
+	DestinationUuid types.String    `tfsdk:"destination_uuid"`
+	EnvironmentName types.String    `tfsdk:"environment_name"`
+	EnvironmentUuid types.String    `tfsdk:"environment_uuid"`
+	ProjectUuid     types.String    `tfsdk:"project_uuid"`
+	ServerUuid      types.String    `tfsdk:"server_uuid"`
+	TeamId          types.Int64     `tfsdk:"team_id"`
+	InstantDeploy   types.Bool      `tfsdk:"instant_deploy"`
+	Compose         sutil.YAMLValue `tfsdk:"compose"`
+	ComposeServices types.Map       `tfsdk:"compose_services"`
+
+	DeletionProtection types.Bool                `tfsdk:"deletion_protection"`
+	DeleteOptions      *sutil.DeleteOptionsModel `tfsdk:"delete_options"`
+}
+
+// serviceFieldPaths maps API fields named differently to the service schema,
+// so validation errors are reported against the right attribute.
+var serviceFieldPaths = map[string]path.Path{
+	"docker_compose_raw": path.Root("compose"),
 }
 
 func (m ServiceModel) Schema(ctx context.Context) schema.Schema {
-	return schema.Schema{
+	return sutil.MergeResourceSchemas(sutil.DeleteOptionsSchema("service"), schema.Schema{
 		Description: "Create, read, update, and delete a Coolify service resource.",
 		Attributes: map[string]schema.Attribute{
 			"uuid": schema.StringAttribute{
@@ -53,13 +67,10 @@ func (m ServiceModel) Schema(ctx context.Context) schema.Schema {
 				Description: "UUID of the destination if the server has multiple destinations.",
 				Default:     stringdefault.StaticString(""),
 			},
-			"environment_name": schema.StringAttribute{
-				Required:    true,
-				Description: "Name of the environment.",
-			},
+			"environment_name": sutil.PlacementAttribute("Name of the environment.", false),
 			"environment_uuid": schema.StringAttribute{
 				Optional:    true, // todo: should change this to required and optional environment name
-				Description: "UUID of the environment. Will replace environment_name in future.",
+				Description: capability.Describe("UUID of the environment. Will replace environment_name in future.", capability.EnvironmentUuid),
 			},
 			"instant_deploy": schema.BoolAttribute{
 				Optional:    true,
@@ -67,24 +78,23 @@ func (m ServiceModel) Schema(ctx context.Context) schema.Schema {
 				Description: "Instant deploy the service.",
 				Default:     booldefault.StaticBool(false),
 			},
-			"project_uuid": schema.StringAttribute{
-				Required:    true,
-				Description: "UUID of the project.",
-			},
-			"server_uuid": schema.StringAttribute{
-				Required:    true,
-				Description: "UUID of the server.",
-			},
+			"project_uuid": sutil.PlacementAttribute("UUID of the project.", false),
+			"server_uuid":  sutil.PlacementAttribute("UUID of the server.", false),
+			"team_id":      sutil.TeamIdAttribute(),
 			"compose": schema.StringAttribute{
 				Required:            true,
 				Description:         "The Docker Compose raw content.",
-				MarkdownDescription: "The Docker Compose raw content.",
+				MarkdownDescription: "The Docker Compose raw content. Formatting or key order changes made by Coolify are not reported as drift.",
+				CustomType:          sutil.YAMLType{},
 			},
+			"compose_services": sutil.ComposeServicesResourceAttribute(),
 		},
-	}
+	})
 }
 
-func (m ServiceModel) FromAPI(service *api.Service, state ServiceModel) ServiceModel {
+func (m ServiceModel) FromAPI(service *api.Service, state ServiceModel) (ServiceModel, diag.Diagnostics) {
+	composeServices, diags := sutil.FlattenComposeServices(service.Applications, service.Databases)
+
 	return ServiceModel{
 		Uuid:            flatten.String(service.Uuid),
 		Name:            flatten.String(service.Name),
@@ -93,10 +103,49 @@ func (m ServiceModel) FromAPI(service *api.Service, state ServiceModel) ServiceM
 		ProjectUuid:     state.ProjectUuid,
 		EnvironmentName: state.EnvironmentName,
 		EnvironmentUuid: state.EnvironmentUuid,
+		TeamId:          state.TeamId,
 		DestinationUuid: state.DestinationUuid,
 		InstantDeploy:   state.InstantDeploy,
-		Compose:         state.Compose,
+		Compose:         flattenCompose(service.DockerComposeRaw, state.Compose),
+		ComposeServices: composeServices,
+
+		DeletionProtection: sutil.DeletionProtectionFromState(state.DeletionProtection),
+		DeleteOptions:      state.DeleteOptions,
+	}, diags
+}
+
+// flattenCompose decodes the compose content returned by the API. The prior value
+// is kept when both are semantically equal, so that formatting differences
+// introduced by Coolify do not show up as drift.
+func flattenCompose(compose *string, prior sutil.YAMLValue) sutil.YAMLValue {
+	if compose == nil {
+		return prior
+	}
+
+	// The content may be returned base64 encoded, as it is sent, or as plain YAML
+	decoded := sutil.Base64Decode(compose)
+	if decoded == nil {
+		decoded = compose
+	}
+
+	if !prior.IsNull() && !prior.IsUnknown() {
+		if equal, err := sutil.YAMLEqual(prior.ValueString(), *decoded); err == nil && equal {
+			return prior
+		}
 	}
+
+	return sutil.NewYAMLValue(*decoded)
+}
+
+// composeUnchanged reports whether the planned compose content is the same
+// YAML document as the one in state.
+func composeUnchanged(ctx context.Context, plan, state sutil.YAMLValue) bool {
+	if plan.IsUnknown() || plan.IsNull() || state.IsUnknown() || state.IsNull() {
+		return false
+	}
+
+	equal, _ := state.StringSemanticEquals(ctx, plan)
+	return equal
 }
 
 func (m ServiceModel) ToAPICreate() api.CreateServiceJSONRequestBody {
@@ -109,7 +158,7 @@ func (m ServiceModel) ToAPICreate() api.CreateServiceJSONRequestBody {
 		ProjectUuid:      m.ProjectUuid.ValueString(),
 		ServerUuid:       m.ServerUuid.ValueString(),
 		InstantDeploy:    m.InstantDeploy.ValueBoolPointer(),
-		DockerComposeRaw: sutil.Base64EncodeAttr(m.Compose),
+		DockerComposeRaw: sutil.Base64EncodeAttr(m.Compose.StringValue),
 	}
 }
 func (m ServiceModel) ToAPIUpdate() api.UpdateServiceByUuidJSONRequestBody {
@@ -122,6 +171,6 @@ func (m ServiceModel) ToAPIUpdate() api.UpdateServiceByUuidJSONRequestBody {
 		ProjectUuid:      m.ProjectUuid.ValueString(),
 		ServerUuid:       m.ServerUuid.ValueString(),
 		InstantDeploy:    m.InstantDeploy.ValueBoolPointer(),
-		DockerComposeRaw: *sutil.Base64EncodeAttr(m.Compose),
+		DockerComposeRaw: *sutil.Base64EncodeAttr(m.Compose.StringValue),
 	}
 }
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/stretchr/testify/assert"
+
+	sutil "terraform-provider-coolify/internal/service/util"
+)
+
+func TestFlattenCompose(t *testing.T) {
+	compose := "services:\n  whoami:\n    image: containous/whoami\n"
+	reformatted := "services:\n    whoami:\n        image: 'containous/whoami'\n"
+	changed := "services:\n  whoami2:\n    image: containous/whoami\n"
+	encoded := func(s string) *string {
+		e := base64.StdEncoding.EncodeToString([]byte(s))
+		return &e
+	}
+
+	tests := []struct {
+		name     string
+		compose  *string
+		prior    sutil.YAMLValue
+		expected sutil.YAMLValue
+	}{
+		{"not returned", nil, sutil.NewYAMLValue(compose), sutil.NewYAMLValue(compose)},
+		{"plain, no prior", &compose, sutil.NewYAMLNull(), sutil.NewYAMLValue(compose)},
+		{"base64, no prior", encoded(compose), sutil.NewYAMLNull(), sutil.NewYAMLValue(compose)},
+		{"reformatted keeps prior", &reformatted, sutil.NewYAMLValue(compose), sutil.NewYAMLValue(compose)},
+		{"base64 reformatted keeps prior", encoded(reformatted), sutil.NewYAMLValue(compose), sutil.NewYAMLValue(compose)},
+		{"drift", &changed, sutil.NewYAMLValue(compose), sutil.NewYAMLValue(changed)},
+		{"base64 drift", encoded(changed), sutil.NewYAMLValue(compose), sutil.NewYAMLValue(changed)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, flattenCompose(tt.compose, tt.prior))
+		})
+	}
+}
+
+func TestComposeUnchanged(t *testing.T) {
+	compose := sutil.NewYAMLValue("services:\n  whoami:\n    image: containous/whoami\n")
+	reformatted := sutil.NewYAMLValue("services:\n    whoami:\n        image: 'containous/whoami'\n")
+	changed := sutil.NewYAMLValue("services:\n  whoami2:\n    image: containous/whoami\n")
+	unknown := sutil.YAMLValue{StringValue: basetypes.NewStringUnknown()}
+
+	tests := []struct {
+		name     string
+		plan     sutil.YAMLValue
+		state    sutil.YAMLValue
+		expected bool
+	}{
+		{"same", compose, compose, true},
+		{"reformatted", reformatted, compose, true},
+		{"changed", changed, compose, false},
+		{"unknown plan", unknown, compose, false},
+		{"null state", compose, sutil.NewYAMLNull(), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, composeUnchanged(context.Background(), tt.plan, tt.state))
+		})
+	}
+}
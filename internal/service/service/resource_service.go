@@ -13,13 +13,18 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"terraform-provider-coolify/internal/api"
+	"terraform-provider-coolify/internal/capability"
+	"terraform-provider-coolify/internal/flatten"
 	"terraform-provider-coolify/internal/provider/util"
+	sutil "terraform-provider-coolify/internal/service/util"
 )
 
 var (
 	_ resource.Resource                = &ServiceResource{}
 	_ resource.ResourceWithConfigure   = &ServiceResource{}
 	_ resource.ResourceWithImportState = &ServiceResource{}
+	_ resource.ResourceWithIdentity    = &ServiceResource{}
+	_ resource.ResourceWithModifyPlan  = &ServiceResource{}
 )
 
 type ServiceResourceModel = ServiceModel
@@ -29,7 +34,7 @@ func NewServiceResource() resource.Resource {
 }
 
 type ServiceResource struct {
-	client *api.ClientWithResponses
+	providerData *util.ProviderData
 }
 
 func (r *ServiceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -41,7 +46,7 @@ func (r *ServiceResource) Schema(ctx context.Context, req resource.SchemaRequest
 }
 
 func (r *ServiceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
-	util.ProviderDataFromResourceConfigureRequest(req, &r.client, resp)
+	util.ProviderDataFromResourceConfigureRequest(req, &r.providerData, resp)
 }
 
 func (r *ServiceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -56,7 +61,12 @@ func (r *ServiceResource) Create(ctx context.Context, req resource.CreateRequest
 		"name": plan.Name.ValueString(),
 	})
 
-	res, err := r.client.CreateServiceWithResponse(ctx, plan.ToAPICreate())
+	client := r.providerData.ClientForTeam(plan.TeamId, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	res, err := client.CreateServiceWithResponse(ctx, plan.ToAPICreate())
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -67,6 +77,9 @@ func (r *ServiceResource) Create(ctx context.Context, req resource.CreateRequest
 	}
 
 	if res.StatusCode() != http.StatusCreated {
+		if sutil.AddValidationErrors(ctx, &resp.Diagnostics, req.Plan.Schema, serviceFieldPaths, "Validation error creating service", res.StatusCode(), res.Body) {
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Unexpected HTTP status code creating service",
 			fmt.Sprintf("Received %s creating service. Details: %s", res.Status(), res.Body),
@@ -76,6 +89,7 @@ func (r *ServiceResource) Create(ctx context.Context, req resource.CreateRequest
 
 	data, _ := r.ReadFromAPI(ctx, &resp.Diagnostics, *res.JSON201.Uuid, plan)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	sutil.SetIdentity(ctx, resp.Identity, &resp.Diagnostics, sutil.UuidIdentityModel{Uuid: data.Uuid, TeamId: data.TeamId})
 }
 
 func (r *ServiceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -100,6 +114,7 @@ func (r *ServiceResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	sutil.SetIdentity(ctx, resp.Identity, &resp.Diagnostics, sutil.UuidIdentityModel{Uuid: data.Uuid, TeamId: data.TeamId})
 }
 
 func (r *ServiceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -122,7 +137,12 @@ func (r *ServiceResource) Update(ctx context.Context, req resource.UpdateRequest
 		"uuid": uuid,
 	})
 
-	updateResp, err := r.client.UpdateServiceByUuidWithResponse(ctx, uuid, plan.ToAPIUpdate())
+	client := r.providerData.ClientForTeam(plan.TeamId, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateResp, err := client.UpdateServiceByUuidWithResponse(ctx, uuid, plan.ToAPIUpdate())
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -133,6 +153,9 @@ func (r *ServiceResource) Update(ctx context.Context, req resource.UpdateRequest
 	}
 
 	if updateResp.StatusCode() != http.StatusOK {
+		if sutil.AddValidationErrors(ctx, &resp.Diagnostics, req.Plan.Schema, serviceFieldPaths, "Validation error updating service", updateResp.StatusCode(), updateResp.Body) {
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Unexpected HTTP status code updating service",
 			fmt.Sprintf("Received %s updating service: uuid=%s. Details: %s", updateResp.Status(), uuid, updateResp.Body))
@@ -140,7 +163,7 @@ func (r *ServiceResource) Update(ctx context.Context, req resource.UpdateRequest
 	}
 
 	if plan.InstantDeploy.ValueBool() {
-		r.client.RestartServiceByUuid(ctx, uuid)
+		client.RestartServiceByUuid(ctx, uuid)
 	}
 
 	data, ok := r.ReadFromAPI(ctx, &resp.Diagnostics, uuid, plan)
@@ -148,7 +171,13 @@ func (r *ServiceResource) Update(ctx context.Context, req resource.UpdateRequest
 		resp.State.RemoveResource(ctx)
 		return
 	}
+	if !plan.ComposeServices.IsUnknown() {
+		// Kept from state as the compose content is unchanged. Status changes
+		// caused by a restart are picked up by the next refresh.
+		data.ComposeServices = plan.ComposeServices
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	sutil.SetIdentity(ctx, resp.Identity, &resp.Diagnostics, sutil.UuidIdentityModel{Uuid: data.Uuid, TeamId: data.TeamId})
 }
 
 func (r *ServiceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -161,14 +190,28 @@ func (r *ServiceResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
+	if !sutil.CheckDeletionProtection(&resp.Diagnostics, state.DeletionProtection, "service", state.Uuid.ValueString()) {
+		return
+	}
+
+	params := state.DeleteOptions.Params()
 	tflog.Debug(ctx, "Deleting service", map[string]interface{}{
-		"uuid": state.Uuid.ValueString(),
+		"uuid":                      state.Uuid.ValueString(),
+		"delete_configurations":     *params.DeleteConfigurations,
+		"delete_volumes":            *params.DeleteVolumes,
+		"docker_cleanup":            *params.DockerCleanup,
+		"delete_connected_networks": *params.DeleteConnectedNetworks,
 	})
-	deleteResp, err := r.client.DeleteServiceByUuidWithResponse(ctx, state.Uuid.ValueString(), &api.DeleteServiceByUuidParams{
-		DeleteConfigurations:    types.BoolValue(true).ValueBoolPointer(),
-		DeleteVolumes:           types.BoolValue(true).ValueBoolPointer(),
-		DockerCleanup:           types.BoolValue(true).ValueBoolPointer(),
-		DeleteConnectedNetworks: types.BoolValue(false).ValueBoolPointer(),
+	client := r.providerData.ClientForTeam(state.TeamId, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteResp, err := client.DeleteServiceByUuidWithResponse(ctx, state.Uuid.ValueString(), &api.DeleteServiceByUuidParams{
+		DeleteConfigurations:    params.DeleteConfigurations,
+		DeleteVolumes:           params.DeleteVolumes,
+		DockerCleanup:           params.DockerCleanup,
+		DeleteConnectedNetworks: params.DeleteConnectedNetworks,
 	})
 
 	if err != nil {
@@ -179,27 +222,67 @@ func (r *ServiceResource) Delete(ctx context.Context, req resource.DeleteRequest
 	if deleteResp.JSON200 == nil {
 		resp.Diagnostics.AddError(
 			"Unexpected HTTP status code deleting service",
-			fmt.Sprintf("Received %s deleting service: %s. Details: %s", deleteResp.Status(), state, deleteResp.Body))
+			fmt.Sprintf("Received %s deleting service: %s. Details: %s", deleteResp.Status(), state.Uuid.ValueString(), deleteResp.Body))
 		return
 	}
 }
 
+func (r *ServiceResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = sutil.UuidIdentitySchema("service")
+}
+
 func (r *ServiceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID == "" {
+		// Imported by identity, server, project and environment are resolved during Read
+		sutil.ImportUuidState(ctx, req, resp)
+		return
+	}
+
 	ids := strings.Split(req.ID, "/")
-	if len(ids) != 4 {
+	if len(ids) == 2 || len(ids) == 5 {
+		sutil.SetImportTeamId(ctx, ids[len(ids)-1], resp)
+		ids = ids[:len(ids)-1]
+	}
+
+	switch len(ids) {
+	case 1:
+		// Server, project and environment are resolved from the API during Read
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("uuid"), ids[0])...)
+	case 4:
+		serverUuid, projectUuid, environmentName, uuid := ids[0], ids[1], ids[2], ids[3]
+
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("server_uuid"), serverUuid)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_uuid"), projectUuid)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment_name"), environmentName)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("uuid"), uuid)...)
+	default:
 		resp.Diagnostics.AddError(
 			"Invalid import ID",
-			"Import ID should be in the format: <server_uuid>/<project_uuid>/<environment_name>/<service_uuid>",
+			"Import ID should be in the format: <service_uuid> or <server_uuid>/<project_uuid>/<environment_name>/<service_uuid>, optionally followed by /<team_id>",
 		)
-		return
 	}
+}
 
-	serverUuid, projectUuid, environmentName, uuid := ids[0], ids[1], ids[2], ids[3]
+func (r *ServiceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.providerData != nil {
+		capability.RequireAttribute(ctx, r.providerData.Version, req.Config, path.Root("environment_uuid"), capability.EnvironmentUuid, &resp.Diagnostics)
+		sutil.ApplyPlacementDefaults(ctx, req, resp, r.providerData.Defaults.Placement())
+		r.providerData.CheckPlannedTeam(ctx, req.Plan, &resp.Diagnostics)
+	}
 
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("server_uuid"), serverUuid)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_uuid"), projectUuid)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment_name"), environmentName)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("uuid"), uuid)...)
+	// The compose services only change with the compose content
+	if !req.Plan.Raw.IsNull() && !req.State.Raw.IsNull() {
+		var plan, state ServiceResourceModel
+		resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if composeUnchanged(ctx, plan.Compose, state.Compose) {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("compose_services"), state.ComposeServices)...)
+		}
+	}
 }
 
 // MARK: Helper functions
@@ -210,7 +293,12 @@ func (r *ServiceResource) ReadFromAPI(
 	uuid string,
 	state ServiceResourceModel,
 ) (ServiceResourceModel, bool) {
-	res, err := r.client.GetServiceByUuidWithResponse(ctx, uuid)
+	client := r.providerData.ClientForTeam(state.TeamId, diags)
+	if client == nil {
+		return ServiceResourceModel{}, false
+	}
+
+	res, err := client.GetServiceByUuidWithResponse(ctx, uuid)
 	if err != nil {
 		diags.AddError(
 			fmt.Sprintf("Error reading service: uuid=%s", uuid),
@@ -230,7 +318,108 @@ func (r *ServiceResource) ReadFromAPI(
 		return ServiceResourceModel{}, false
 	}
 
-	result := ServiceResourceModel{}.FromAPI(res.JSON200, state)
+	result, d := ServiceResourceModel{}.FromAPI(res.JSON200, state)
+	diags.Append(d...)
+
+	if result.ServerUuid.IsNull() {
+		result.ServerUuid = r.resolveServerUuid(ctx, diags, client, res.JSON200.ServerId)
+	}
+	if result.ProjectUuid.IsNull() || result.EnvironmentName.IsNull() {
+		result.ProjectUuid, result.EnvironmentName = r.resolveEnvironment(ctx, diags, client, res.JSON200.EnvironmentId)
+	}
 
 	return result, true
 }
+
+// resolveServerUuid looks up the UUID of the server with the given ID, as the
+// service only references its server by ID.
+func (r *ServiceResource) resolveServerUuid(
+	ctx context.Context,
+	diags *diag.Diagnostics,
+	client *api.ClientWithResponses,
+	serverId *int,
+) types.String {
+	if serverId == nil {
+		return types.StringNull()
+	}
+
+	res, err := client.ListServersWithResponse(ctx)
+	if err != nil {
+		diags.AddError("Error reading servers", err.Error())
+		return types.StringNull()
+	}
+
+	if res.StatusCode() != http.StatusOK {
+		diags.AddError(
+			"Unexpected HTTP status code reading servers",
+			fmt.Sprintf("Received %s for servers. Details: %s", res.Status(), res.Body))
+		return types.StringNull()
+	}
+
+	for _, server := range *res.JSON200 {
+		if server.Id != nil && *server.Id == *serverId {
+			return flatten.String(server.Uuid)
+		}
+	}
+
+	diags.AddWarning("Server not found", fmt.Sprintf("Unable to find the server of the service: server_id=%d", *serverId))
+	return types.StringNull()
+}
+
+// resolveEnvironment looks up the project UUID and environment name of the
+// environment with the given ID, as the service only references its
+// environment by ID.
+func (r *ServiceResource) resolveEnvironment(
+	ctx context.Context,
+	diags *diag.Diagnostics,
+	client *api.ClientWithResponses,
+	environmentId *int,
+) (types.String, types.String) {
+	if environmentId == nil {
+		return types.StringNull(), types.StringNull()
+	}
+
+	listResp, err := client.ListProjectsWithResponse(ctx)
+	if err != nil {
+		diags.AddError("Error reading projects", err.Error())
+		return types.StringNull(), types.StringNull()
+	}
+
+	if listResp.StatusCode() != http.StatusOK {
+		diags.AddError(
+			"Unexpected HTTP status code reading projects",
+			fmt.Sprintf("Received %s for projects. Details: %s", listResp.Status(), listResp.Body))
+		return types.StringNull(), types.StringNull()
+	}
+
+	for _, project := range *listResp.JSON200 {
+		environments := project.Environments
+		if environments == nil && project.Uuid != nil {
+			// Environments are not always included when listing projects
+			projectResp, err := client.GetProjectByUuidWithResponse(ctx, *project.Uuid)
+			if err != nil {
+				diags.AddError(fmt.Sprintf("Error reading project: uuid=%s", *project.Uuid), err.Error())
+				return types.StringNull(), types.StringNull()
+			}
+			if projectResp.StatusCode() != http.StatusOK {
+				diags.AddError(
+					"Unexpected HTTP status code reading project",
+					fmt.Sprintf("Received %s for project: uuid=%s. Details: %s", projectResp.Status(), *project.Uuid, projectResp.Body))
+				return types.StringNull(), types.StringNull()
+			}
+			environments = projectResp.JSON200.Environments
+		}
+		if environments == nil {
+			continue
+		}
+
+		for _, environment := range *environments {
+			if environment.Id != nil && *environment.Id == *environmentId {
+				return flatten.String(project.Uuid), flatten.String(environment.Name)
+			}
+		}
+	}
+
+	diags.AddWarning("Environment not found", fmt.Sprintf("Unable to find the environment of the service: environment_id=%d", *environmentId))
+	return types.StringNull(), types.StringNull()
+}
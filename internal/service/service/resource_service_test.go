@@ -8,8 +8,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
 	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 
 	"terraform-provider-coolify/internal/acctest"
 )
@@ -50,6 +52,10 @@ EOF
 
 					resource.TestCheckResourceAttrSet(resName, "uuid"),
 					resource.TestCheckResourceAttrSet(resName, "compose"),
+					resource.TestCheckResourceAttr(resName, "compose_services.%", "1"),
+					resource.TestCheckResourceAttr(resName, "compose_services.whoami.type", "application"),
+					resource.TestCheckResourceAttr(resName, "compose_services.whoami.image", "containous/whoami"),
+					resource.TestCheckResourceAttrSet(resName, "compose_services.whoami.uuid"),
 				),
 			},
 			{ // ImportState testing
@@ -70,6 +76,16 @@ EOF
 					), nil
 				},
 			},
+			{ // ImportState by UUID only testing
+				ResourceName:                         resName,
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "uuid",
+				ImportStateVerifyIgnore:              []string{"instant_deploy"},
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return s.RootModule().Resources[resName].Primary.Attributes["uuid"], nil
+				},
+			},
 			{ // Update and Read testing
 				Config: `
 				resource "coolify_service" "test" {
@@ -97,6 +113,7 @@ EOF
 						plancheck.ExpectResourceAction(resName, plancheck.ResourceActionUpdate),
 						plancheck.ExpectKnownValue(resName, tfjsonpath.New("name"), knownvalue.StringExact("TerraformAccTestUpdated")),
 						plancheck.ExpectKnownValue(resName, tfjsonpath.New("description"), knownvalue.StringExact("Terraform acceptance testing")),
+						plancheck.ExpectUnknownValue(resName, tfjsonpath.New("compose_services")),
 					},
 					PostApplyPostRefresh: []plancheck.PlanCheck{
 						plancheck.ExpectResourceAction(resName, plancheck.ResourceActionNoop),
@@ -109,6 +126,93 @@ EOF
 					resource.TestCheckResourceAttr(resName, "server_uuid", acctest.ServerUUID),
 				),
 			},
+			{ // Reformatting the compose content is applied once, without perpetual drift
+				Config: `
+				resource "coolify_service" "test" {
+					name        = "TerraformAccTestUpdated"
+					description = "Terraform acceptance testing"
+
+					server_uuid = "` + acctest.ServerUUID + `"
+					project_uuid = "` + acctest.ProjectUUID + `"
+					environment_name = "` + acctest.EnvironmentName + `"
+					destination_uuid = "` + acctest.DestinationUUID + `"
+
+					instant_deploy = false
+
+  				compose = <<EOF
+# Keys reordered, re-indented and unquoted
+services:
+    whoami2:
+        container_name: simple-service
+        image: containous/whoami
+EOF
+
+				}
+				`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resName, plancheck.ResourceActionUpdate),
+						plancheck.ExpectKnownValue(resName, tfjsonpath.New("compose_services").AtMapKey("whoami2").AtMapKey("image"), knownvalue.StringExact("containous/whoami")),
+					},
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resName, plancheck.ResourceActionNoop),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAccServiceResource_Identity(t *testing.T) {
+	resName := "coolify_service.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			{ // Create and Read testing
+				Config: `
+				resource "coolify_service" "test" {
+					name        = "TerraformAccTest"
+					description = "Terraform acceptance testing"
+
+					server_uuid = "` + acctest.ServerUUID + `"
+					project_uuid = "` + acctest.ProjectUUID + `"
+					environment_name = "` + acctest.EnvironmentName + `"
+					destination_uuid = "` + acctest.DestinationUUID + `"
+
+					instant_deploy = false
+  				compose = <<EOF
+services:
+  whoami:
+    image: "containous/whoami"
+    container_name: "simple-service"
+EOF
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentity(resName, map[string]knownvalue.Check{
+						"uuid":    knownvalue.NotNull(),
+						"team_id": knownvalue.Null(),
+					}),
+					statecheck.ExpectIdentityValueMatchesState(resName, tfjsonpath.New("uuid")),
+				},
+			},
+			{ // ImportState by identity testing
+				ResourceName:    resName,
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+				// instant_deploy is not returned by the API, so it is set by the first apply
+				ExpectNonEmptyPlan: true,
+				ImportPlanChecks: resource.ImportPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resName, plancheck.ResourceActionUpdate),
+						plancheck.ExpectKnownValue(resName, tfjsonpath.New("environment_name"), knownvalue.StringExact(acctest.EnvironmentName)),
+					},
+				},
+			},
 		},
 	})
 }
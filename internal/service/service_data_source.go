@@ -7,11 +7,13 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"terraform-provider-coolify/internal/api"
 	"terraform-provider-coolify/internal/flatten"
 	"terraform-provider-coolify/internal/provider/generated/datasource_service"
 	"terraform-provider-coolify/internal/provider/util"
+	sutil "terraform-provider-coolify/internal/service/util"
 )
 
 var _ datasource.DataSource = &serviceDataSource{}
@@ -25,6 +27,11 @@ type serviceDataSource struct {
 	client *api.ClientWithResponses
 }
 
+type serviceDataSourceModel struct {
+	datasource_service.ServiceModel
+	ComposeServices types.Map `tfsdk:"compose_services"`
+}
+
 func (d *serviceDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_service"
 }
@@ -32,6 +39,7 @@ func (d *serviceDataSource) Metadata(ctx context.Context, req datasource.Metadat
 func (d *serviceDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = datasource_service.ServiceDataSourceSchema(ctx)
 	resp.Schema.Description = "Get a Coolify service by `uuid`."
+	resp.Schema.Attributes["compose_services"] = sutil.ComposeServicesDataSourceAttribute()
 
 	// Mark sensitive attributes
 	sensitiveAttrs := []string{"manual_webhook_secret_bitbucket", "manual_webhook_secret_gitea", "manual_webhook_secret_github", "manual_webhook_secret_gitlab"}
@@ -45,7 +53,7 @@ func (d *serviceDataSource) Configure(ctx context.Context, req datasource.Config
 }
 
 func (d *serviceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var plan datasource_service.ServiceModel
+	var plan serviceDataSourceModel
 
 	// Read Terraform configuration data into the model
 	resp.Diagnostics.Append(req.Config.Get(ctx, &plan)...)
@@ -79,25 +87,31 @@ func (d *serviceDataSource) ApiToModel(
 	ctx context.Context,
 	diags *diag.Diagnostics,
 	response *api.Service,
-) datasource_service.ServiceModel {
-	return datasource_service.ServiceModel{
-		ConfigHash:                      flatten.String(response.ConfigHash),
-		ConnectToDockerNetwork:          flatten.Bool(response.ConnectToDockerNetwork),
-		CreatedAt:                       flatten.String(response.CreatedAt),
-		DeletedAt:                       flatten.String(response.DeletedAt),
-		Description:                     flatten.String(response.Description),
-		DestinationId:                   flatten.Int64(response.DestinationId),
-		DestinationType:                 flatten.String(response.DestinationType),
-		DockerCompose:                   flatten.String(response.DockerCompose),
-		DockerComposeRaw:                flatten.String(response.DockerComposeRaw),
-		EnvironmentId:                   flatten.Int64(response.EnvironmentId),
-		Id:                              flatten.Int64(response.Id),
-		IsContainerLabelEscapeEnabled:   flatten.Bool(response.IsContainerLabelEscapeEnabled),
-		IsContainerLabelReadonlyEnabled: flatten.Bool(response.IsContainerLabelReadonlyEnabled),
-		Name:                            flatten.String(response.Name),
-		ServerId:                        flatten.Int64(response.ServerId),
-		ServiceType:                     flatten.String((*string)(response.ServiceType)), // enum value
-		UpdatedAt:                       flatten.String(response.UpdatedAt),
-		Uuid:                            flatten.String(response.Uuid),
+) serviceDataSourceModel {
+	composeServices, diag := sutil.FlattenComposeServices(response.Applications, response.Databases)
+	diags.Append(diag...)
+
+	return serviceDataSourceModel{
+		ServiceModel: datasource_service.ServiceModel{
+			ConfigHash:                      flatten.String(response.ConfigHash),
+			ConnectToDockerNetwork:          flatten.Bool(response.ConnectToDockerNetwork),
+			CreatedAt:                       flatten.String(response.CreatedAt),
+			DeletedAt:                       flatten.String(response.DeletedAt),
+			Description:                     flatten.String(response.Description),
+			DestinationId:                   flatten.Int64(response.DestinationId),
+			DestinationType:                 flatten.String(response.DestinationType),
+			DockerCompose:                   flatten.String(response.DockerCompose),
+			DockerComposeRaw:                flatten.String(response.DockerComposeRaw),
+			EnvironmentId:                   flatten.Int64(response.EnvironmentId),
+			Id:                              flatten.Int64(response.Id),
+			IsContainerLabelEscapeEnabled:   flatten.Bool(response.IsContainerLabelEscapeEnabled),
+			IsContainerLabelReadonlyEnabled: flatten.Bool(response.IsContainerLabelReadonlyEnabled),
+			Name:                            flatten.String(response.Name),
+			ServerId:                        flatten.Int64(response.ServerId),
+			ServiceType:                     flatten.String((*string)(response.ServiceType)), // enum value
+			UpdatedAt:                       flatten.String(response.UpdatedAt),
+			Uuid:                            flatten.String(response.Uuid),
+		},
+		ComposeServices: composeServices,
 	}
 }
@@ -24,6 +24,7 @@ func TestAccServiceDataSource(t *testing.T) {
 					resource.TestCheckResourceAttrSet(resName, "docker_compose"),
 					resource.TestCheckResourceAttr(resName, "name", "service-"+acctest.ServiceUUID),
 					resource.TestCheckNoResourceAttr(resName, "description"),
+					resource.TestCheckResourceAttrSet(resName, "compose_services.%"),
 				),
 			},
 		},
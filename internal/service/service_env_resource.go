@@ -0,0 +1,437 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-coolify/internal/api"
+	"terraform-provider-coolify/internal/flatten"
+	"terraform-provider-coolify/internal/provider/generated/resource_service_envs"
+	"terraform-provider-coolify/internal/provider/util"
+	sutil "terraform-provider-coolify/internal/service/util"
+)
+
+var (
+	_ resource.Resource                = &serviceEnvResource{}
+	_ resource.ResourceWithConfigure   = &serviceEnvResource{}
+	_ resource.ResourceWithImportState = &serviceEnvResource{}
+	_ resource.ResourceWithIdentity    = &serviceEnvResource{}
+	_ resource.ResourceWithModifyPlan  = &serviceEnvResource{}
+)
+
+func NewServiceEnvResource() resource.Resource {
+	return &serviceEnvResource{}
+}
+
+type serviceEnvResource struct {
+	providerData *util.ProviderData
+}
+
+type serviceEnvResourceModel struct {
+	ServiceUuid    types.String `tfsdk:"service_uuid"`
+	Uuid           types.String `tfsdk:"uuid"`
+	Key            types.String `tfsdk:"key"`
+	Value          types.String `tfsdk:"value"`
+	ValueWo        types.String `tfsdk:"value_wo"`
+	ValueWoVersion types.Int64  `tfsdk:"value_wo_version"`
+	TeamId         types.Int64  `tfsdk:"team_id"`
+	IsBuildTime    types.Bool   `tfsdk:"is_build_time"`
+	IsLiteral      types.Bool   `tfsdk:"is_literal"`
+	IsMultiline    types.Bool   `tfsdk:"is_multiline"`
+	IsShownOnce    types.Bool   `tfsdk:"is_shown_once"`
+}
+
+type serviceEnvIdentityModel struct {
+	ServiceUuid types.String `tfsdk:"service_uuid"`
+	Key         types.String `tfsdk:"key"`
+	TeamId      types.Int64  `tfsdk:"team_id"`
+}
+
+func (m serviceEnvResourceModel) Identity() serviceEnvIdentityModel {
+	return serviceEnvIdentityModel{
+		ServiceUuid: m.ServiceUuid,
+		Key:         m.Key,
+		TeamId:      m.TeamId,
+	}
+}
+
+func (r *serviceEnvResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_service_env"
+}
+
+func (r *serviceEnvResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	codegenSchema := resource_service_envs.ServiceEnvsResourceSchema(ctx)
+
+	resp.Schema = schema.Schema{
+		Description: "Create, read, update, and delete a single Service environment variable.",
+		MarkdownDescription: "Create, read, update, and delete a single Service environment variable.\n\n" +
+			"Unlike `coolify_service_envs`, each variable is managed on its own, so several modules can safely contribute variables to the same service. " +
+			"A variable is identified by its `service_uuid` and `key`.",
+		Attributes: envFlagAttributes(codegenSchema.Attributes),
+	}
+
+	resp.Schema.Attributes["service_uuid"] = schema.StringAttribute{
+		Required:      true,
+		Description:   "UUID of the service.",
+		PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+	}
+	resp.Schema.Attributes["uuid"] = schema.StringAttribute{
+		Computed:      true,
+		Description:   "UUID of the environment variable.",
+		PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+	}
+	resp.Schema.Attributes["key"] = schema.StringAttribute{
+		Required:      true,
+		Description:   "The key of the environment variable.",
+		PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+	}
+	// Preview deployments are not supported on services
+	delete(resp.Schema.Attributes, "is_preview")
+
+	resp.Schema.Attributes["team_id"] = sutil.TeamIdAttribute()
+
+	for name, attribute := range writeOnlyAttributes("value", "The value of the environment variable", "Change this value to update the environment variable.") {
+		resp.Schema.Attributes[name] = attribute
+	}
+}
+
+func (r *serviceEnvResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	util.ProviderDataFromResourceConfigureRequest(req, &r.providerData, resp)
+}
+
+func (r *serviceEnvResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan serviceEnvResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	uuid := plan.ServiceUuid.ValueString()
+	tflog.Debug(ctx, "Creating service env", map[string]interface{}{
+		"uuid": uuid,
+		"key":  plan.Key.ValueString(),
+	})
+
+	client := r.providerData.ClientForTeam(plan.TeamId, &resp.Diagnostics)
+	value := writeOnlyValueForAPI(ctx, req.Config, &resp.Diagnostics, "value", plan.Value, plan.ValueWoVersion, types.Int64Null())
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createResp, err := client.CreateEnvByServiceUuidWithResponse(ctx, uuid, api.CreateEnvByServiceUuidJSONRequestBody{
+		IsBuildTime: plan.IsBuildTime.ValueBoolPointer(),
+		IsLiteral:   plan.IsLiteral.ValueBoolPointer(),
+		IsMultiline: plan.IsMultiline.ValueBoolPointer(),
+		IsShownOnce: plan.IsShownOnce.ValueBoolPointer(),
+		Key:         plan.Key.ValueStringPointer(),
+		Value:       value,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating service env",
+			err.Error(),
+		)
+		return
+	}
+
+	if createResp.StatusCode() == http.StatusConflict {
+		resp.Diagnostics.AddError(
+			"Service env already exists",
+			fmt.Sprintf("The service %s already has an env with key %s. Import it with ID %s to manage it.",
+				uuid, plan.Key.ValueString(), serviceEnvImportID(plan)),
+		)
+		return
+	}
+
+	if createResp.StatusCode() != http.StatusCreated {
+		if sutil.AddValidationErrors(ctx, &resp.Diagnostics, req.Plan.Schema, nil, "Validation error creating service env", createResp.StatusCode(), createResp.Body) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Unexpected HTTP status code creating service env",
+			fmt.Sprintf("Received %s creating service env: uuid=%s, key=%s. Details: %s", createResp.Status(), uuid, plan.Key.ValueString(), createResp.Body),
+		)
+		return
+	}
+
+	data, ok := r.readFromAPI(ctx, &resp.Diagnostics, client, uuid, plan.Key.ValueString())
+	if !ok {
+		if !resp.Diagnostics.HasError() {
+			resp.Diagnostics.AddError(
+				"Service env not found",
+				fmt.Sprintf("Service env was not found after creation: uuid=%s, key=%s", uuid, plan.Key.ValueString()),
+			)
+		}
+		return
+	}
+
+	data.Value = flattenWriteOnlyValue(data.Value.ValueStringPointer(), plan.ValueWoVersion)
+	data.ValueWoVersion = plan.ValueWoVersion
+	data.TeamId = plan.TeamId
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	sutil.SetIdentity(ctx, resp.Identity, &resp.Diagnostics, data.Identity())
+}
+
+func (r *serviceEnvResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state serviceEnvResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading service env", map[string]interface{}{
+		"uuid": state.ServiceUuid.ValueString(),
+		"key":  state.Key.ValueString(),
+	})
+
+	client := r.providerData.ClientForTeam(state.TeamId, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data, ok := r.readFromAPI(ctx, &resp.Diagnostics, client, state.ServiceUuid.ValueString(), state.Key.ValueString())
+	if !ok {
+		if !resp.Diagnostics.HasError() {
+			resp.State.RemoveResource(ctx)
+		}
+		return
+	}
+
+	data.Value = flattenWriteOnlyValue(data.Value.ValueStringPointer(), state.ValueWoVersion)
+	data.ValueWoVersion = state.ValueWoVersion
+	data.TeamId = state.TeamId
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	sutil.SetIdentity(ctx, resp.Identity, &resp.Diagnostics, data.Identity())
+}
+
+func (r *serviceEnvResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state serviceEnvResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	uuid := plan.ServiceUuid.ValueString()
+	tflog.Debug(ctx, "Updating service env", map[string]interface{}{
+		"uuid": uuid,
+		"key":  plan.Key.ValueString(),
+	})
+
+	client := r.providerData.ClientForTeam(plan.TeamId, &resp.Diagnostics)
+	value := writeOnlyValueForAPI(ctx, req.Config, &resp.Diagnostics, "value", plan.Value, plan.ValueWoVersion, state.ValueWoVersion)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if value == nil {
+		// The write-only value did not change, so send the value stored in Coolify
+		current, ok := r.readFromAPI(ctx, &resp.Diagnostics, client, uuid, plan.Key.ValueString())
+		if !ok {
+			if !resp.Diagnostics.HasError() {
+				resp.Diagnostics.AddError(
+					"Service env not found",
+					fmt.Sprintf("Service env was not found: uuid=%s, key=%s", uuid, plan.Key.ValueString()),
+				)
+			}
+			return
+		}
+		value = current.Value.ValueStringPointer()
+	}
+
+	updateResp, err := client.UpdateEnvByServiceUuidWithResponse(ctx, uuid, api.UpdateEnvByServiceUuidJSONRequestBody{
+		IsBuildTime: plan.IsBuildTime.ValueBoolPointer(),
+		IsLiteral:   plan.IsLiteral.ValueBoolPointer(),
+		IsMultiline: plan.IsMultiline.ValueBoolPointer(),
+		IsShownOnce: plan.IsShownOnce.ValueBoolPointer(),
+		Key:         plan.Key.ValueString(),
+		Value:       *value,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Error updating service env: uuid=%s, key=%s", uuid, plan.Key.ValueString()),
+			err.Error(),
+		)
+		return
+	}
+
+	if updateResp.StatusCode() != http.StatusCreated {
+		if sutil.AddValidationErrors(ctx, &resp.Diagnostics, req.Plan.Schema, nil, "Validation error updating service env", updateResp.StatusCode(), updateResp.Body) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Unexpected HTTP status code updating service env",
+			fmt.Sprintf("Received %s updating service env: uuid=%s, key=%s. Details: %s", updateResp.Status(), uuid, plan.Key.ValueString(), updateResp.Body))
+		return
+	}
+
+	data, ok := r.readFromAPI(ctx, &resp.Diagnostics, client, uuid, plan.Key.ValueString())
+	if !ok {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Value = flattenWriteOnlyValue(data.Value.ValueStringPointer(), plan.ValueWoVersion)
+	data.ValueWoVersion = plan.ValueWoVersion
+	data.TeamId = plan.TeamId
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	sutil.SetIdentity(ctx, resp.Identity, &resp.Diagnostics, data.Identity())
+}
+
+func (r *serviceEnvResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state serviceEnvResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	uuid := state.ServiceUuid.ValueString()
+	tflog.Debug(ctx, "Deleting service env", map[string]interface{}{
+		"uuid": uuid,
+		"key":  state.Key.ValueString(),
+	})
+
+	client := r.providerData.ClientForTeam(state.TeamId, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteResp, err := client.DeleteEnvByServiceUuidWithResponse(ctx, uuid, state.Uuid.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete service env, got error: %s", err))
+		return
+	}
+
+	if deleteResp.StatusCode() != http.StatusOK && deleteResp.StatusCode() != http.StatusNotFound {
+		resp.Diagnostics.AddError(
+			"Unexpected HTTP status code deleting service env",
+			fmt.Sprintf("Received %s deleting service env: uuid=%s, key=%s. Details: %s", deleteResp.Status(), uuid, state.Key.ValueString(), deleteResp.Body))
+		return
+	}
+}
+
+func (r *serviceEnvResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"service_uuid": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "UUID of the service.",
+			},
+			"key": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "Key of the environment variable.",
+			},
+			"team_id": sutil.TeamIdIdentityAttribute(),
+		},
+	}
+}
+
+func (r *serviceEnvResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID == "" {
+		var identity serviceEnvIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("service_uuid"), identity.ServiceUuid)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("key"), identity.Key)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("team_id"), identity.TeamId)...)
+		return
+	}
+
+	ids := strings.Split(req.ID, "/")
+	if (len(ids) != 2 && len(ids) != 3) || ids[0] == "" || ids[1] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			"Import ID should be in the format: <service_uuid>/<key>, optionally followed by /<team_id>",
+		)
+		return
+	}
+	if len(ids) == 3 {
+		sutil.SetImportTeamId(ctx, ids[2], resp)
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("service_uuid"), ids[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("key"), ids[1])...)
+}
+
+func (r *serviceEnvResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.providerData != nil {
+		r.providerData.CheckPlannedTeam(ctx, req.Plan, &resp.Diagnostics)
+	}
+}
+
+// MARK: Helper Functions
+
+func serviceEnvImportID(m serviceEnvResourceModel) string {
+	id := m.ServiceUuid.ValueString() + "/" + m.Key.ValueString()
+	if !m.TeamId.IsNull() {
+		id += "/" + m.TeamId.String()
+	}
+	return id
+}
+
+// readFromAPI finds the env identified by key, returning false when either the
+// service or the env does not exist.
+func (r *serviceEnvResource) readFromAPI(
+	ctx context.Context,
+	diags *diag.Diagnostics,
+	client *api.ClientWithResponses,
+	uuid string,
+	key string,
+) (serviceEnvResourceModel, bool) {
+	readResp, err := client.ListEnvsByServiceUuidWithResponse(ctx, uuid)
+	if err != nil {
+		diags.AddError(
+			fmt.Sprintf("Error reading service envs: uuid=%s", uuid),
+			err.Error(),
+		)
+		return serviceEnvResourceModel{}, false
+	}
+
+	if readResp.StatusCode() == http.StatusNotFound {
+		return serviceEnvResourceModel{}, false
+	}
+
+	if readResp.StatusCode() != http.StatusOK {
+		diags.AddError(
+			"Unexpected HTTP status code reading service envs",
+			fmt.Sprintf("Received %s for service envs: uuid=%s. Details: %s", readResp.Status(), uuid, readResp.Body))
+		return serviceEnvResourceModel{}, false
+	}
+
+	for _, env := range *readResp.JSON200 {
+		if env.Key == nil || *env.Key != key || (env.IsPreview != nil && *env.IsPreview) {
+			continue
+		}
+
+		return serviceEnvResourceModel{
+			ServiceUuid: types.StringValue(uuid),
+			Uuid:        flatten.String(env.Uuid),
+			Key:         flatten.String(env.Key),
+			Value:       flatten.String(env.Value),
+			IsBuildTime: flatten.Bool(env.IsBuildTime),
+			IsLiteral:   flatten.Bool(env.IsLiteral),
+			IsMultiline: flatten.Bool(env.IsMultiline),
+			IsShownOnce: flatten.Bool(env.IsShownOnce),
+		}, true
+	}
+
+	return serviceEnvResourceModel{}, false
+}
@@ -0,0 +1,116 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+
+	"terraform-provider-coolify/internal/acctest"
+	"terraform-provider-coolify/internal/service"
+)
+
+func TestAccServiceEnvResource(t *testing.T) {
+	resName := "coolify_service_env.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{ // Create and Read testing
+				Config: `
+					resource "coolify_service_env" "test" {
+						service_uuid     = "` + acctest.ServiceUUID + `"
+						key              = "TF_ACC_SINGLE"
+						value            = "value1"
+					}`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue(resName, tfjsonpath.New("is_build_time"), knownvalue.Bool(false)),
+						plancheck.ExpectKnownValue(resName, tfjsonpath.New("is_literal"), knownvalue.Bool(false)),
+						plancheck.ExpectKnownValue(resName, tfjsonpath.New("is_multiline"), knownvalue.Bool(false)),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resName, "service_uuid", acctest.ServiceUUID),
+					resource.TestCheckResourceAttr(resName, "key", "TF_ACC_SINGLE"),
+					resource.TestCheckResourceAttr(resName, "value", "value1"),
+					resource.TestCheckResourceAttrSet(resName, "uuid"),
+				),
+			},
+			{ // ImportState testing
+				ResourceName:                         resName,
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateId:                        acctest.ServiceUUID + "/TF_ACC_SINGLE",
+				ImportStateVerifyIdentifierAttribute: "uuid",
+			},
+			{ // Update and Read testing
+				Config: `
+					resource "coolify_service_env" "test" {
+						service_uuid     = "` + acctest.ServiceUUID + `"
+						key              = "TF_ACC_SINGLE"
+						value            = "value2"
+					}`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resName, plancheck.ResourceActionUpdate),
+					},
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resName, plancheck.ResourceActionNoop),
+					},
+				},
+				Check: resource.TestCheckResourceAttr(resName, "value", "value2"),
+			},
+		},
+	})
+}
+
+func TestAccServiceEnvResource_Identity(t *testing.T) {
+	resName := "coolify_service_env.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			{ // Create and Read testing
+				Config: `
+					resource "coolify_service_env" "test" {
+						service_uuid     = "` + acctest.ServiceUUID + `"
+						key              = "TF_ACC_SINGLE"
+						value            = "value1"
+					}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentity(resName, map[string]knownvalue.Check{
+						"service_uuid": knownvalue.StringExact(acctest.ServiceUUID),
+						"key":          knownvalue.StringExact("TF_ACC_SINGLE"),
+						"team_id":      knownvalue.Null(),
+					}),
+				},
+			},
+			{ // ImportState by identity testing
+				ResourceName:    resName,
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
+
+func TestServiceEnvResourceSchema(t *testing.T) {
+	ctx := context.Background()
+	rs := service.NewServiceEnvResource()
+	resp := &tfresource.SchemaResponse{}
+	rs.Schema(ctx, tfresource.SchemaRequest{}, resp)
+
+	if diags := resp.Schema.ValidateImplementation(ctx); diags.HasError() {
+		t.Fatalf("schema validation diagnostics: %+v", diags)
+	}
+}
@@ -3,12 +3,16 @@ package service_test
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	tfresource "github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
 	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 
 	"terraform-provider-coolify/internal/acctest"
 	"terraform-provider-coolify/internal/service"
@@ -39,8 +43,10 @@ func TestAccServiceEnvsResource(t *testing.T) {
 					}`,
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttrSet(resName, "env.#"),
-					resource.TestCheckResourceAttr(resName, "env.0.key", "key1"),
-					resource.TestCheckResourceAttr(resName, "env.0.value", "value1"),
+					resource.TestCheckTypeSetElemNestedAttrs(resName, "env.*", map[string]string{"key": "key1", "value": "value1"}),
+					resource.TestCheckTypeSetElemNestedAttrs(resName, "env.*", map[string]string{"key": "key2", "value": "value2"}),
+					resource.TestCheckResourceAttr(resName, "env_uuids.%", "3"),
+					resource.TestCheckResourceAttrSet(resName, "env_uuids.key1"),
 				),
 			},
 			{ // ImportState testing
@@ -87,12 +93,73 @@ func TestAccServiceEnvsResource(t *testing.T) {
 				},
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttrSet(resName, "env.#"),
-					resource.TestCheckResourceAttr(resName, "env.0.key", "key1-1"),
-					resource.TestCheckResourceAttr(resName, "env.0.value", "value1-1"),
-					resource.TestCheckResourceAttr(resName, "env.1.key", "key2"),
-					resource.TestCheckResourceAttr(resName, "env.1.value", "value2-2"),
+					resource.TestCheckTypeSetElemNestedAttrs(resName, "env.*", map[string]string{"key": "key1-1", "value": "value1-1"}),
+					resource.TestCheckTypeSetElemNestedAttrs(resName, "env.*", map[string]string{"key": "key2", "value": "value2-2"}),
 				),
 			},
+			{ // Reordering envs is a no-op
+				Config: `
+					resource "coolify_service_envs" "test" {
+						uuid = "` + acctest.ServiceUUID + `"
+						env {
+							key        = "key2"
+							value      = "value2-2"
+						}
+						env {
+							key        = "key1-1"
+							value      = "value1-1"
+						}
+					}`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resName, plancheck.ResourceActionNoop),
+					},
+				},
+			},
+			{ // Duplicate keys are rejected at plan time
+				Config: `
+					resource "coolify_service_envs" "test" {
+						uuid = "` + acctest.ServiceUUID + `"
+						env {
+							key        = "key2"
+							value      = "value2-2"
+						}
+						env {
+							key        = "key2"
+							value      = "value2-3"
+						}
+					}`,
+				ExpectError: regexp.MustCompile("Duplicate environment variable"),
+			},
+		},
+	})
+}
+
+func TestAccServiceEnvsResource_Identity(t *testing.T) {
+	resName := "coolify_service_envs.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acctest.TestAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			{ // Create and Read testing
+				Config: `
+					resource "coolify_service_envs" "test" {
+						uuid = "` + acctest.ServiceUUID + `"
+						env {
+							key   = "key1"
+							value = "value1"
+						}
+					}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentity(resName, map[string]knownvalue.Check{
+						"uuid":    knownvalue.StringExact(acctest.ServiceUUID),
+						"team_id": knownvalue.Null(),
+					}),
+				},
+			},
 		},
 	})
 }
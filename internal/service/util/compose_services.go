@@ -0,0 +1,181 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	ds_schema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	res_schema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gopkg.in/yaml.v3"
+
+	"terraform-provider-coolify/internal/api"
+	"terraform-provider-coolify/internal/flatten"
+)
+
+const (
+	ComposeServiceTypeApplication = "application"
+	ComposeServiceTypeDatabase    = "database"
+)
+
+const composeServicesDescription = "Applications and databases Coolify parsed from the compose file, keyed by compose service name."
+
+// ComposeServiceAttributeTypes are the attribute types of an element of the
+// `compose_services` attribute of services.
+var ComposeServiceAttributeTypes = map[string]attr.Type{
+	"uuid":       types.StringType,
+	"type":       types.StringType,
+	"human_name": types.StringType,
+	"image":      types.StringType,
+	"fqdns":      types.ListType{ElemType: types.StringType},
+	"status":     types.StringType,
+}
+
+var composeServiceAttributeDescriptions = map[string]string{
+	"uuid":       "UUID of the service application or database.",
+	"type":       "Type of the compose service, either `" + ComposeServiceTypeApplication + "` or `" + ComposeServiceTypeDatabase + "`.",
+	"human_name": "Display name of the compose service.",
+	"image":      "Docker image of the compose service.",
+	"fqdns":      "FQDNs of the compose service. Always empty for databases.",
+	"status":     "Status of the compose service, e.g. `running:healthy`.",
+}
+
+// ComposeServicesResourceAttribute returns the computed `compose_services`
+// attribute for resource schemas.
+func ComposeServicesResourceAttribute() res_schema.MapNestedAttribute {
+	attributes := map[string]res_schema.Attribute{}
+	for name, description := range composeServiceAttributeDescriptions {
+		if name == "fqdns" {
+			attributes[name] = res_schema.ListAttribute{ElementType: types.StringType, Computed: true, MarkdownDescription: description}
+		} else {
+			attributes[name] = res_schema.StringAttribute{Computed: true, MarkdownDescription: description}
+		}
+	}
+
+	return res_schema.MapNestedAttribute{
+		Computed:            true,
+		MarkdownDescription: composeServicesDescription,
+		NestedObject:        res_schema.NestedAttributeObject{Attributes: attributes},
+	}
+}
+
+// ComposeServicesDataSourceAttribute returns the computed `compose_services`
+// attribute for data source schemas.
+func ComposeServicesDataSourceAttribute() ds_schema.MapNestedAttribute {
+	attributes := map[string]ds_schema.Attribute{}
+	for name, description := range composeServiceAttributeDescriptions {
+		if name == "fqdns" {
+			attributes[name] = ds_schema.ListAttribute{ElementType: types.StringType, Computed: true, MarkdownDescription: description}
+		} else {
+			attributes[name] = ds_schema.StringAttribute{Computed: true, MarkdownDescription: description}
+		}
+	}
+
+	return ds_schema.MapNestedAttribute{
+		Computed:            true,
+		MarkdownDescription: composeServicesDescription,
+		NestedObject:        ds_schema.NestedAttributeObject{Attributes: attributes},
+	}
+}
+
+// FlattenComposeServices converts the applications and databases Coolify parsed
+// from a service's compose file into a map keyed by compose service name.
+func FlattenComposeServices(applications *[]api.ServiceApplication, databases *[]api.ServiceDatabase) (types.Map, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	elemType := types.ObjectType{AttrTypes: ComposeServiceAttributeTypes}
+	elements := map[string]attr.Value{}
+
+	add := func(name *string, attributes map[string]attr.Value) {
+		if name == nil {
+			return
+		}
+		value, d := types.ObjectValue(ComposeServiceAttributeTypes, attributes)
+		diags.Append(d...)
+		elements[*name] = value
+	}
+
+	if applications != nil {
+		for _, app := range *applications {
+			add(app.Name, map[string]attr.Value{
+				"uuid":       flatten.String(app.Uuid),
+				"type":       types.StringValue(ComposeServiceTypeApplication),
+				"human_name": flatten.String(app.HumanName),
+				"image":      flatten.String(app.Image),
+				"fqdns":      flatten.StringList(splitFqdn(app.Fqdn)),
+				"status":     flatten.String(app.Status),
+			})
+		}
+	}
+
+	if databases != nil {
+		for _, db := range *databases {
+			add(db.Name, map[string]attr.Value{
+				"uuid":       flatten.String(db.Uuid),
+				"type":       types.StringValue(ComposeServiceTypeDatabase),
+				"human_name": flatten.String(db.HumanName),
+				"image":      flatten.String(db.Image),
+				"fqdns":      flatten.StringList(&[]string{}),
+				"status":     flatten.String(db.Status),
+			})
+		}
+	}
+
+	result, d := types.MapValue(elemType, elements)
+	diags.Append(d...)
+	return result, diags
+}
+
+// splitFqdn splits the comma separated FQDNs Coolify stores for an application.
+func splitFqdn(fqdn *string) *[]string {
+	fqdns := []string{}
+	if fqdn == nil {
+		return &fqdns
+	}
+
+	for _, value := range strings.Split(*fqdn, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			fqdns = append(fqdns, value)
+		}
+	}
+	return &fqdns
+}
+
+// ComposeServiceNames returns the names of the services defined in a compose
+// file, in the order they are defined.
+func ComposeServiceNames(compose string) ([]string, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(compose), &document); err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	if len(document.Content) == 0 {
+		return names, nil
+	}
+
+	root := document.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("expected a mapping at the top level of the compose file")
+	}
+
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value != "services" {
+			continue
+		}
+
+		services := root.Content[i+1]
+		if services.Kind == yaml.ScalarNode && services.Tag == "!!null" {
+			break
+		}
+		if services.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("expected `services` to be a mapping, at line %d", services.Line)
+		}
+		for j := 0; j < len(services.Content); j += 2 {
+			names = append(names, services.Content[j].Value)
+		}
+	}
+
+	return names, nil
+}
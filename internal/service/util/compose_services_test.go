@@ -0,0 +1,124 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+
+	"terraform-provider-coolify/internal/api"
+)
+
+func TestFlattenComposeServices(t *testing.T) {
+	str := func(s string) *string { return &s }
+
+	applications := []api.ServiceApplication{
+		{
+			Uuid:   str("app-uuid"),
+			Name:   str("web"),
+			Fqdn:   str("https://web.example.com, https://www.example.com"),
+			Image:  str("nginx:latest"),
+			Status: str("running:healthy"),
+		},
+		{Uuid: str("no-name")},
+	}
+	databases := []api.ServiceDatabase{
+		{
+			Uuid:      str("db-uuid"),
+			Name:      str("db"),
+			HumanName: str("Database"),
+			Image:     str("postgres:16"),
+			Status:    str("exited"),
+		},
+	}
+
+	result, diags := FlattenComposeServices(&applications, &databases)
+	assert.False(t, diags.HasError())
+	assert.Len(t, result.Elements(), 2)
+
+	web := result.Elements()["web"].(types.Object).Attributes()
+	assert.Equal(t, types.StringValue("app-uuid"), web["uuid"])
+	assert.Equal(t, types.StringValue(ComposeServiceTypeApplication), web["type"])
+	assert.Equal(t, types.StringNull(), web["human_name"])
+	assert.Equal(t, types.StringValue("nginx:latest"), web["image"])
+	assert.Equal(t, types.ListValueMust(types.StringType, []attr.Value{
+		types.StringValue("https://web.example.com"),
+		types.StringValue("https://www.example.com"),
+	}), web["fqdns"])
+	assert.Equal(t, types.StringValue("running:healthy"), web["status"])
+
+	db := result.Elements()["db"].(types.Object).Attributes()
+	assert.Equal(t, types.StringValue(ComposeServiceTypeDatabase), db["type"])
+	assert.Equal(t, types.StringValue("Database"), db["human_name"])
+	assert.Equal(t, types.ListValueMust(types.StringType, []attr.Value{}), db["fqdns"])
+}
+
+func TestFlattenComposeServices_Empty(t *testing.T) {
+	result, diags := FlattenComposeServices(nil, nil)
+	assert.False(t, diags.HasError())
+	assert.False(t, result.IsNull())
+	assert.Empty(t, result.Elements())
+}
+
+func TestSplitFqdn(t *testing.T) {
+	tests := []struct {
+		name     string
+		fqdn     *string
+		expected []string
+	}{
+		{"nil", nil, []string{}},
+		{"empty", &[]string{""}[0], []string{}},
+		{"single", &[]string{"https://a.example.com"}[0], []string{"https://a.example.com"}},
+		{"multiple", &[]string{"https://a.example.com,http://b.example.com:8080"}[0], []string{"https://a.example.com", "http://b.example.com:8080"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, *splitFqdn(tt.fqdn))
+		})
+	}
+}
+
+func TestComposeServiceNames(t *testing.T) {
+	tests := []struct {
+		name      string
+		compose   string
+		expected  []string
+		expectErr bool
+	}{
+		{
+			name: "in definition order",
+			compose: `
+services:
+  web:
+    image: nginx
+  db:
+    image: postgres
+  cache:
+    image: redis
+volumes:
+  data: {}
+`,
+			expected: []string{"web", "db", "cache"},
+		},
+		{name: "no services", compose: "volumes: {}", expected: []string{}},
+		{name: "empty services", compose: "services:", expected: []string{}},
+		{name: "empty", compose: "", expected: []string{}},
+		{name: "invalid yaml", compose: "services: [", expectErr: true},
+		{name: "not a mapping", compose: "- web", expectErr: true},
+		{name: "services not a mapping", compose: "services: [web]", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			names, err := ComposeServiceNames(tt.compose)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package util
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// DeleteOptionsModel describes what Coolify removes alongside a resource when
+// it is destroyed. A nil model uses the defaults.
+type DeleteOptionsModel struct {
+	KeepVolumes             types.Bool `tfsdk:"keep_volumes"`
+	KeepConfigurations      types.Bool `tfsdk:"keep_configurations"`
+	DockerCleanup           types.Bool `tfsdk:"docker_cleanup"`
+	DeleteConnectedNetworks types.Bool `tfsdk:"delete_connected_networks"`
+}
+
+// DeleteParams holds the query parameters of Coolify's delete endpoints.
+type DeleteParams struct {
+	DeleteConfigurations    *bool
+	DeleteVolumes           *bool
+	DockerCleanup           *bool
+	DeleteConnectedNetworks *bool
+}
+
+// Params converts the options to the query parameters of the delete endpoints.
+func (m *DeleteOptionsModel) Params() DeleteParams {
+	if m == nil {
+		m = &DeleteOptionsModel{}
+	}
+
+	return DeleteParams{
+		DeleteConfigurations:    types.BoolValue(!m.KeepConfigurations.ValueBool()).ValueBoolPointer(),
+		DeleteVolumes:           types.BoolValue(!m.KeepVolumes.ValueBool()).ValueBoolPointer(),
+		DockerCleanup:           types.BoolValue(m.DockerCleanup.IsNull() || m.DockerCleanup.ValueBool()).ValueBoolPointer(),
+		DeleteConnectedNetworks: types.BoolValue(m.DeleteConnectedNetworks.ValueBool()).ValueBoolPointer(),
+	}
+}
+
+// DeleteOptionsSchema returns the `delete_options` block and
+// `deletion_protection` attribute for resources that can be deleted with
+// Coolify's delete options.
+func DeleteOptionsSchema(resourceName string) schema.Schema {
+	return schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"deletion_protection": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Description:         fmt.Sprintf("Prevent the %s from being destroyed. Must be set to false and applied before the %s can be destroyed or replaced.", resourceName, resourceName),
+				MarkdownDescription: fmt.Sprintf("Prevent the %s from being destroyed. Must be set to `false` and applied before the %s can be destroyed or replaced.", resourceName, resourceName),
+				Default:             booldefault.StaticBool(false),
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"delete_options": schema.SingleNestedBlock{
+				Description: fmt.Sprintf("Options for what is removed alongside the %s when it is destroyed.", resourceName),
+				Attributes: map[string]schema.Attribute{
+					"keep_volumes": schema.BoolAttribute{
+						Optional:            true,
+						Description:         "Keep the persistent volumes. Defaults to false.",
+						MarkdownDescription: "Keep the persistent volumes. Defaults to `false`.",
+					},
+					"keep_configurations": schema.BoolAttribute{
+						Optional:            true,
+						Description:         "Keep the configuration files on the server. Defaults to false.",
+						MarkdownDescription: "Keep the configuration files on the server. Defaults to `false`.",
+					},
+					"docker_cleanup": schema.BoolAttribute{
+						Optional:            true,
+						Description:         "Run a Docker cleanup on the server afterwards. Defaults to true.",
+						MarkdownDescription: "Run a Docker cleanup on the server afterwards. Defaults to `true`.",
+					},
+					"delete_connected_networks": schema.BoolAttribute{
+						Optional:            true,
+						Description:         "Delete the Docker networks connected to the resource. Defaults to false.",
+						MarkdownDescription: "Delete the Docker networks connected to the resource. Defaults to `false`.",
+					},
+				},
+			},
+		},
+	}
+}
+
+// DeletionProtectionFromState returns the prior deletion protection value,
+// defaulting to false when it is not known, e.g. after an import.
+func DeletionProtectionFromState(state types.Bool) types.Bool {
+	if state.IsNull() || state.IsUnknown() {
+		return types.BoolValue(false)
+	}
+	return state
+}
+
+// CheckDeletionProtection adds an error diagnostic when deletion protection is
+// enabled, returning whether the resource may be deleted.
+func CheckDeletionProtection(diags *diag.Diagnostics, enabled types.Bool, resourceName string, uuid string) bool {
+	if !enabled.ValueBool() {
+		return true
+	}
+
+	diags.AddError(
+		"Deletion protection enabled",
+		fmt.Sprintf("Cannot delete %s %s while deletion_protection is enabled. Set deletion_protection to false and apply before destroying it.", resourceName, uuid),
+	)
+	return false
+}
@@ -0,0 +1,51 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteOptionsParams(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  *DeleteOptionsModel
+		expected [4]bool // configurations, volumes, docker cleanup, connected networks
+	}{
+		{"no block", nil, [4]bool{true, true, true, false}},
+		{"empty block", &DeleteOptionsModel{}, [4]bool{true, true, true, false}},
+		{"keep volumes", &DeleteOptionsModel{KeepVolumes: types.BoolValue(true)}, [4]bool{true, false, true, false}},
+		{"keep configurations", &DeleteOptionsModel{KeepConfigurations: types.BoolValue(true)}, [4]bool{false, true, true, false}},
+		{"no docker cleanup", &DeleteOptionsModel{DockerCleanup: types.BoolValue(false)}, [4]bool{true, true, false, false}},
+		{"delete networks", &DeleteOptionsModel{DeleteConnectedNetworks: types.BoolValue(true)}, [4]bool{true, true, true, true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := tt.options.Params()
+			assert.Equal(t, tt.expected, [4]bool{
+				*params.DeleteConfigurations,
+				*params.DeleteVolumes,
+				*params.DockerCleanup,
+				*params.DeleteConnectedNetworks,
+			})
+		})
+	}
+}
+
+func TestCheckDeletionProtection(t *testing.T) {
+	var diags diag.Diagnostics
+	assert.True(t, CheckDeletionProtection(&diags, types.BoolValue(false), "database", "abc"))
+	assert.True(t, CheckDeletionProtection(&diags, types.BoolNull(), "database", "abc"))
+	assert.False(t, diags.HasError())
+
+	assert.False(t, CheckDeletionProtection(&diags, types.BoolValue(true), "database", "abc"))
+	assert.True(t, diags.HasError())
+}
+
+func TestDeletionProtectionFromState(t *testing.T) {
+	assert.Equal(t, types.BoolValue(false), DeletionProtectionFromState(types.BoolNull()))
+	assert.Equal(t, types.BoolValue(true), DeletionProtectionFromState(types.BoolValue(true)))
+}
@@ -0,0 +1,52 @@
+package util
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// UuidIdentityModel is the identity of resources identified by their UUID.
+type UuidIdentityModel struct {
+	Uuid   types.String `tfsdk:"uuid"`
+	TeamId types.Int64  `tfsdk:"team_id"`
+}
+
+// UuidIdentitySchema returns the identity schema of resources identified by
+// their UUID.
+func UuidIdentitySchema(resourceName string) identityschema.Schema {
+	return identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"uuid": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       fmt.Sprintf("UUID of the %s.", resourceName),
+			},
+			"team_id": TeamIdIdentityAttribute(),
+		},
+	}
+}
+
+// TeamIdIdentityAttribute returns the identity attribute holding the
+// `team_id` of a resource, so that importing by identity reads the resource
+// with the token of its team.
+func TeamIdIdentityAttribute() identityschema.Int64Attribute {
+	return identityschema.Int64Attribute{
+		OptionalForImport: true,
+		Description:       "ID of the team owning the resource, when it is managed with a token from the provider `team_tokens`.",
+	}
+}
+
+// SetIdentity sets the identity of a resource from the given model. The
+// identity is nil when Terraform does not support resource identity, in which
+// case nothing is set.
+func SetIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, diags *diag.Diagnostics, model any) {
+	if identity == nil {
+		return
+	}
+
+	diags.Append(identity.Set(ctx, model)...)
+}
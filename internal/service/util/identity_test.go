@@ -0,0 +1,35 @@
+package util
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetIdentity(t *testing.T) {
+	ctx := context.Background()
+	schema := UuidIdentitySchema("server")
+	identity := &tfsdk.ResourceIdentity{
+		Schema: schema,
+		Raw:    tftypes.NewValue(schema.Type().TerraformType(ctx), nil),
+	}
+
+	var diags diag.Diagnostics
+	SetIdentity(ctx, identity, &diags, UuidIdentityModel{Uuid: types.StringValue("uuid"), TeamId: types.Int64Value(3)})
+	assert.False(t, diags.HasError())
+
+	var result UuidIdentityModel
+	diags.Append(identity.Get(ctx, &result)...)
+	assert.False(t, diags.HasError())
+	assert.Equal(t, types.StringValue("uuid"), result.Uuid)
+	assert.Equal(t, types.Int64Value(3), result.TeamId)
+
+	// Terraform versions without resource identity support
+	SetIdentity(ctx, nil, &diags, UuidIdentityModel{Uuid: types.StringValue("uuid")})
+	assert.False(t, diags.HasError())
+}
@@ -0,0 +1,31 @@
+package util
+
+import (
+	"iter"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+)
+
+// ListResults streams a list result for each item, skipping the items for
+// which result returns false. Streaming stops once limit results have been
+// pushed, unless limit is 0.
+func ListResults[T any](items []T, limit int64, result func(item T) (list.ListResult, bool)) iter.Seq[list.ListResult] {
+	return func(push func(list.ListResult) bool) {
+		var count int64
+		for _, item := range items {
+			if limit > 0 && count >= limit {
+				return
+			}
+
+			res, ok := result(item)
+			if !ok {
+				continue
+			}
+
+			count++
+			if !push(res) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListResults(t *testing.T) {
+	items := []string{"a", "skip", "b", "c"}
+	result := func(item string) (list.ListResult, bool) {
+		return list.ListResult{DisplayName: item}, item != "skip"
+	}
+	names := func(limit int64, stopAfter int) []string {
+		var names []string
+		for res := range ListResults(items, limit, result) {
+			names = append(names, res.DisplayName)
+			if len(names) == stopAfter {
+				break
+			}
+		}
+		return names
+	}
+
+	assert.Equal(t, []string{"a", "b", "c"}, names(0, -1))
+	assert.Equal(t, []string{"a", "b"}, names(2, -1))
+	assert.Equal(t, []string{"a"}, names(0, 1))
+
+	for range ListResults([]string{}, 0, result) {
+		t.Fatal("expected no results")
+	}
+}
@@ -0,0 +1,127 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// PlacementAttribute returns an attribute placing a resource, such as its
+// server or project, that falls back to the provider `defaults` when not set.
+// The value is kept once created, so changing the provider defaults does not
+// move existing resources.
+func PlacementAttribute(description string, requiresReplace bool) schema.StringAttribute {
+	modifiers := []planmodifier.String{stringplanmodifier.UseStateForUnknown()}
+	if requiresReplace {
+		modifiers = append(modifiers, stringplanmodifier.RequiresReplace())
+	}
+
+	return schema.StringAttribute{
+		Optional:      true,
+		Computed:      true,
+		Description:   description + " Defaults to the provider `defaults`.",
+		PlanModifiers: modifiers,
+	}
+}
+
+// TeamIdAttribute returns the attribute selecting which of the provider
+// `team_tokens` manages a resource. Coolify cannot move resources between
+// teams, so changing it replaces the resource.
+func TeamIdAttribute() schema.Int64Attribute {
+	return schema.Int64Attribute{
+		Optional:      true,
+		Description:   "ID of the team owning the resource, managed with the token set for it in the provider `team_tokens`. Defaults to the team of the provider token. To import a resource of another team, append the team ID to the import ID.",
+		PlanModifiers: []planmodifier.Int64{int64planmodifier.RequiresReplace()},
+	}
+}
+
+// SetImportTeamId sets the `team_id` of an imported resource from the team
+// ID appended to its import ID, so that it is read with the token of that
+// team.
+func SetImportTeamId(ctx context.Context, teamId string, resp *resource.ImportStateResponse) {
+	parsed, err := strconv.ParseInt(teamId, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("The team ID appended to the import ID must be a whole number, got: %q", teamId),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("team_id"), types.Int64Value(parsed))...)
+}
+
+// ImportUuidState imports a resource identified by its UUID, either from its
+// resource identity or from an import ID in the format `<uuid>`, optionally
+// followed by `/<team_id>`.
+func ImportUuidState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID == "" {
+		var identity UuidIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("uuid"), identity.Uuid)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("team_id"), identity.TeamId)...)
+		return
+	}
+
+	ids := strings.Split(req.ID, "/")
+	if len(ids) > 2 {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			"Import ID should be in the format: <uuid>, optionally followed by /<team_id>",
+		)
+		return
+	}
+	if len(ids) == 2 {
+		SetImportTeamId(ctx, ids[1], resp)
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("uuid"), ids[0])...)
+}
+
+// ApplyPlacementDefaults fills placement attributes that are not configured
+// with the provider defaults when a resource is created, keyed by attribute
+// name. Attributes without either are reported as missing.
+func ApplyPlacementDefaults(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, defaults map[string]string) {
+	if req.Plan.Raw.IsNull() || !req.State.Raw.IsNull() {
+		return
+	}
+
+	attributes := make([]string, 0, len(defaults))
+	for attribute := range defaults {
+		attributes = append(attributes, attribute)
+	}
+	sort.Strings(attributes)
+
+	for _, attribute := range attributes {
+		var configured types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attribute), &configured)...)
+		if !configured.IsNull() {
+			continue
+		}
+
+		if defaults[attribute] == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attribute),
+				"Missing placement",
+				fmt.Sprintf("`%s` must be set on the resource, or as `defaults.%s` in the provider configuration.", attribute, attribute),
+			)
+			continue
+		}
+
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(attribute), defaults[attribute])...)
+	}
+}
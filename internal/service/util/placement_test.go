@@ -0,0 +1,220 @@
+package util
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyPlacementDefaults(t *testing.T) {
+	ctx := context.Background()
+	placementSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"server_uuid":  PlacementAttribute("UUID of the server.", true),
+			"project_uuid": PlacementAttribute("UUID of the project.", true),
+		},
+	}
+	objectType := placementSchema.Type().TerraformType(ctx)
+
+	object := func(server, project interface{}) tftypes.Value {
+		return tftypes.NewValue(objectType, map[string]tftypes.Value{
+			"server_uuid":  tftypes.NewValue(tftypes.String, server),
+			"project_uuid": tftypes.NewValue(tftypes.String, project),
+		})
+	}
+
+	tests := []struct {
+		name            string
+		config          tftypes.Value
+		state           tftypes.Value
+		defaults        map[string]string
+		expectedServer  types.String
+		expectedProject types.String
+		expectedError   bool
+	}{
+		{
+			name:            "defaults fill unset attributes",
+			config:          object("server", nil),
+			state:           tftypes.NewValue(objectType, nil),
+			defaults:        map[string]string{"server_uuid": "default-server", "project_uuid": "default-project"},
+			expectedServer:  types.StringValue("server"),
+			expectedProject: types.StringValue("default-project"),
+		},
+		{
+			name:          "missing default",
+			config:        object("server", nil),
+			state:         tftypes.NewValue(objectType, nil),
+			defaults:      map[string]string{"server_uuid": "default-server", "project_uuid": ""},
+			expectedError: true,
+		},
+		{
+			name:            "existing resources keep their placement",
+			config:          object(nil, nil),
+			state:           object("server", "project"),
+			defaults:        map[string]string{"server_uuid": "default-server", "project_uuid": "default-project"},
+			expectedServer:  types.StringUnknown(),
+			expectedProject: types.StringUnknown(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Terraform plans unset computed attributes as unknown
+			var configValues map[string]tftypes.Value
+			require.NoError(t, tt.config.As(&configValues))
+			planValues := map[string]tftypes.Value{}
+			for name, value := range configValues {
+				planValues[name] = value
+				if value.IsNull() {
+					planValues[name] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+				}
+			}
+			plan := tfsdk.Plan{Schema: placementSchema, Raw: tftypes.NewValue(objectType, planValues)}
+
+			req := resource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: placementSchema, Raw: tt.config},
+				State:  tfsdk.State{Schema: placementSchema, Raw: tt.state},
+				Plan:   plan,
+			}
+			resp := &resource.ModifyPlanResponse{Plan: plan}
+
+			ApplyPlacementDefaults(ctx, req, resp, tt.defaults)
+
+			assert.Equal(t, tt.expectedError, resp.Diagnostics.HasError(), resp.Diagnostics)
+			if tt.expectedError {
+				return
+			}
+
+			var server, project types.String
+			resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("server_uuid"), &server)...)
+			resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("project_uuid"), &project)...)
+			require.False(t, resp.Diagnostics.HasError())
+			assert.Equal(t, tt.expectedServer, server)
+			assert.Equal(t, tt.expectedProject, project)
+		})
+	}
+}
+
+func TestSetImportTeamId(t *testing.T) {
+	ctx := context.Background()
+	teamSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"team_id": TeamIdAttribute(),
+		},
+	}
+
+	newResponse := func() *resource.ImportStateResponse {
+		return &resource.ImportStateResponse{
+			State: tfsdk.State{Schema: teamSchema, Raw: tftypes.NewValue(teamSchema.Type().TerraformType(ctx), nil)},
+		}
+	}
+
+	resp := newResponse()
+	SetImportTeamId(ctx, "3", resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var teamId types.Int64
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("team_id"), &teamId)...)
+	assert.Equal(t, types.Int64Value(3), teamId)
+
+	resp = newResponse()
+	SetImportTeamId(ctx, "team", resp)
+	assert.True(t, resp.Diagnostics.HasError())
+}
+
+func TestImportUuidState(t *testing.T) {
+	ctx := context.Background()
+	importSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"uuid":    schema.StringAttribute{Computed: true},
+			"team_id": TeamIdAttribute(),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		id             string
+		expectedUuid   types.String
+		expectedTeamId types.Int64
+		expectedError  bool
+	}{
+		{name: "uuid", id: "abc1234", expectedUuid: types.StringValue("abc1234"), expectedTeamId: types.Int64Null()},
+		{name: "uuid and team", id: "abc1234/3", expectedUuid: types.StringValue("abc1234"), expectedTeamId: types.Int64Value(3)},
+		{name: "invalid team", id: "abc1234/team", expectedError: true},
+		{name: "too many segments", id: "abc1234/3/4", expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &resource.ImportStateResponse{
+				State: tfsdk.State{Schema: importSchema, Raw: tftypes.NewValue(importSchema.Type().TerraformType(ctx), nil)},
+			}
+			ImportUuidState(ctx, resource.ImportStateRequest{ID: tt.id}, resp)
+			require.Equal(t, tt.expectedError, resp.Diagnostics.HasError(), resp.Diagnostics)
+			if tt.expectedError {
+				return
+			}
+
+			var uuid types.String
+			var teamId types.Int64
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("uuid"), &uuid)...)
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("team_id"), &teamId)...)
+			assert.Equal(t, tt.expectedUuid, uuid)
+			assert.Equal(t, tt.expectedTeamId, teamId)
+		})
+	}
+}
+
+func TestImportUuidStateFromIdentity(t *testing.T) {
+	ctx := context.Background()
+	importSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"uuid":    schema.StringAttribute{Computed: true},
+			"team_id": TeamIdAttribute(),
+		},
+	}
+	identitySchema := UuidIdentitySchema("server")
+
+	tests := []struct {
+		name   string
+		teamId types.Int64
+	}{
+		{name: "without team", teamId: types.Int64Null()},
+		{name: "with team", teamId: types.Int64Value(3)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity := &tfsdk.ResourceIdentity{
+				Schema: identitySchema,
+				Raw:    tftypes.NewValue(identitySchema.Type().TerraformType(ctx), nil),
+			}
+			var diags diag.Diagnostics
+			SetIdentity(ctx, identity, &diags, UuidIdentityModel{Uuid: types.StringValue("abc1234"), TeamId: tt.teamId})
+			require.False(t, diags.HasError(), diags)
+
+			resp := &resource.ImportStateResponse{
+				State:    tfsdk.State{Schema: importSchema, Raw: tftypes.NewValue(importSchema.Type().TerraformType(ctx), nil)},
+				Identity: identity,
+			}
+			ImportUuidState(ctx, resource.ImportStateRequest{Identity: identity}, resp)
+			require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+			var uuid types.String
+			var teamId types.Int64
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("uuid"), &uuid)...)
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("team_id"), &teamId)...)
+			assert.Equal(t, types.StringValue("abc1234"), uuid)
+			assert.Equal(t, tt.teamId, teamId)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	"terraform-provider-coolify/internal/api"
+)
+
+// ValidationSchema is the part of a schema used to look up the attribute an
+// invalid field refers to. It is implemented by resource schemas, and by the
+// Schema of a plan or state.
+type ValidationSchema interface {
+	TypeAtPath(context.Context, path.Path) (attr.Type, diag.Diagnostics)
+}
+
+// AddValidationErrors adds diagnostics for a 422 response from Coolify, and
+// reports whether the response was a validation error. Each invalid field is
+// reported against the root attribute of the same name, or the path in
+// fieldPaths for fields named differently in the API. Fields without a
+// matching attribute are reported together as a single error. schema may be
+// nil when no fields map to attributes.
+func AddValidationErrors(
+	ctx context.Context,
+	diags *diag.Diagnostics,
+	schema ValidationSchema,
+	fieldPaths map[string]path.Path,
+	summary string,
+	statusCode int,
+	body []byte,
+) bool {
+	validationErr := api.ParseValidationError(statusCode, body)
+	if validationErr == nil {
+		return false
+	}
+
+	if len(validationErr.Errors) == 0 {
+		diags.AddError(summary, validationErr.Message)
+		return true
+	}
+
+	var unmapped []string
+	for _, field := range validationErr.Fields() {
+		messages := validationErr.Errors[field]
+		if attributePath, ok := validationErrorPath(ctx, schema, fieldPaths, field); ok {
+			diags.AddAttributeError(attributePath, summary, strings.Join(messages, "\n"))
+		} else {
+			unmapped = append(unmapped, fmt.Sprintf("%s: %s", field, strings.Join(messages, " ")))
+		}
+	}
+	if len(unmapped) > 0 {
+		diags.AddError(summary, strings.Join(unmapped, "\n"))
+	}
+
+	return true
+}
+
+// validationErrorPath maps a field of a validation error to an attribute path.
+// Nested fields such as `domains.0` are mapped by their first segment.
+func validationErrorPath(ctx context.Context, schema ValidationSchema, fieldPaths map[string]path.Path, field string) (path.Path, bool) {
+	name, _, _ := strings.Cut(field, ".")
+	if attributePath, ok := fieldPaths[name]; ok {
+		return attributePath, true
+	}
+
+	if schema == nil {
+		return path.Empty(), false
+	}
+
+	attributePath := path.Root(name)
+	if _, diags := schema.TypeAtPath(ctx, attributePath); diags.HasError() {
+		return path.Empty(), false
+	}
+	return attributePath, true
+}
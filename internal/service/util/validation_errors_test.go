@@ -0,0 +1,83 @@
+package util
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddValidationErrors(t *testing.T) {
+	ctx := context.Background()
+	testSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name":    schema.StringAttribute{Required: true},
+			"compose": schema.StringAttribute{Required: true},
+			"domains": schema.ListAttribute{Optional: true},
+		},
+	}
+	fieldPaths := map[string]path.Path{"docker_compose_raw": path.Root("compose")}
+
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		expectOk   bool
+		expected   diag.Diagnostics
+	}{
+		{
+			name:       "field errors",
+			statusCode: http.StatusUnprocessableEntity,
+			body: `{"message": "Validation failed.", "errors": {
+				"name": ["The name field is required.", "The name must be unique."],
+				"docker_compose_raw": ["The compose file is invalid."],
+				"domains.0": ["The domain is invalid."],
+				"unknown": ["Something is wrong."],
+				"other": ["Something else is wrong."]
+			}}`,
+			expectOk: true,
+			expected: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(path.Root("compose"), "Validation error", "The compose file is invalid."),
+				diag.NewAttributeErrorDiagnostic(path.Root("domains"), "Validation error", "The domain is invalid."),
+				diag.NewAttributeErrorDiagnostic(path.Root("name"), "Validation error", "The name field is required.\nThe name must be unique."),
+				diag.NewErrorDiagnostic("Validation error", "other: Something else is wrong.\nunknown: Something is wrong."),
+			},
+		},
+		{
+			name:       "message only",
+			statusCode: http.StatusUnprocessableEntity,
+			body:       `{"message": "Server is not reachable."}`,
+			expectOk:   true,
+			expected: diag.Diagnostics{
+				diag.NewErrorDiagnostic("Validation error", "Server is not reachable."),
+			},
+		},
+		{
+			name:       "not a validation error",
+			statusCode: http.StatusInternalServerError,
+			body:       `{"message": "Server error."}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			ok := AddValidationErrors(ctx, &diags, testSchema, fieldPaths, "Validation error", tt.statusCode, []byte(tt.body))
+			assert.Equal(t, tt.expectOk, ok)
+			assert.Equal(t, tt.expected, diags)
+		})
+	}
+
+	t.Run("without schema", func(t *testing.T) {
+		var diags diag.Diagnostics
+		ok := AddValidationErrors(ctx, &diags, nil, nil, "Validation error", http.StatusUnprocessableEntity, []byte(`{"errors": {"data.0.key": ["The key is required."]}}`))
+		assert.True(t, ok)
+		assert.Equal(t, diag.Diagnostics{
+			diag.NewErrorDiagnostic("Validation error", "data.0.key: The key is required."),
+		}, diags)
+	})
+}
@@ -0,0 +1,122 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	_ basetypes.StringTypable                    = YAMLType{}
+	_ basetypes.StringValuableWithSemanticEquals = YAMLValue{}
+)
+
+// YAMLEqual reports whether two YAML documents are semantically equal, ignoring
+// differences in whitespace, comments, quoting and key order.
+func YAMLEqual(a, b string) (bool, error) {
+	var aValue, bValue interface{}
+	if err := yaml.Unmarshal([]byte(a), &aValue); err != nil {
+		return false, err
+	}
+	if err := yaml.Unmarshal([]byte(b), &bValue); err != nil {
+		return false, err
+	}
+
+	return reflect.DeepEqual(aValue, bValue), nil
+}
+
+// YAMLType is a string attribute type holding a YAML document. Values that
+// only differ in formatting or key order are semantically equal, so the
+// formatting Coolify applies to stored documents is not reported as drift.
+type YAMLType struct {
+	basetypes.StringType
+}
+
+func (t YAMLType) String() string {
+	return "util.YAMLType"
+}
+
+func (t YAMLType) ValueType(ctx context.Context) attr.Value {
+	return YAMLValue{}
+}
+
+func (t YAMLType) Equal(o attr.Type) bool {
+	other, ok := o.(YAMLType)
+	if !ok {
+		return false
+	}
+
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t YAMLType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return YAMLValue{StringValue: in}, nil
+}
+
+func (t YAMLType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	return YAMLValue{StringValue: stringValue}, nil
+}
+
+// YAMLValue is a value of YAMLType.
+type YAMLValue struct {
+	basetypes.StringValue
+}
+
+func NewYAMLValue(value string) YAMLValue {
+	return YAMLValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+func NewYAMLNull() YAMLValue {
+	return YAMLValue{StringValue: basetypes.NewStringNull()}
+}
+
+func (v YAMLValue) Type(ctx context.Context) attr.Type {
+	return YAMLType{}
+}
+
+func (v YAMLValue) Equal(o attr.Value) bool {
+	other, ok := o.(YAMLValue)
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals reports whether both values are the same YAML document.
+// Invalid YAML is only equal when the strings are identical.
+func (v YAMLValue) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(YAMLValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got %T. Please report this to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+
+	equal, err := YAMLEqual(v.ValueString(), newValue.ValueString())
+	if err != nil {
+		return v.ValueString() == newValue.ValueString(), diags
+	}
+
+	return equal, diags
+}
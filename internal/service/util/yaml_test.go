@@ -0,0 +1,82 @@
+package util
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+const testCompose = `services:
+  whoami:
+    image: "containous/whoami"
+    container_name: "simple-service"
+`
+
+func TestYAMLEqual(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        string
+		b        string
+		expected bool
+		wantErr  bool
+	}{
+		{"identical", testCompose, testCompose, true, false},
+		{"key order", testCompose, "services:\n  whoami:\n    container_name: simple-service\n    image: containous/whoami\n", true, false},
+		{"indentation and comments", testCompose, "# comment\nservices:\n    whoami:\n        image: 'containous/whoami'\n        container_name: simple-service\n", true, false},
+		{"different value", testCompose, "services:\n  whoami:\n    image: containous/whoami:latest\n    container_name: simple-service\n", false, false},
+		{"different service", testCompose, "services:\n  whoami2:\n    image: containous/whoami\n    container_name: simple-service\n", false, false},
+		{"invalid yaml", testCompose, "services: [", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := YAMLEqual(tt.a, tt.b)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestYAMLValueSemanticEquals(t *testing.T) {
+	reordered := "services:\n  whoami:\n    container_name: simple-service\n    image: containous/whoami\n"
+	changed := "services:\n  whoami2:\n    image: containous/whoami\n"
+
+	tests := []struct {
+		name     string
+		prior    YAMLValue
+		new      YAMLValue
+		expected bool
+	}{
+		{"identical", NewYAMLValue(testCompose), NewYAMLValue(testCompose), true},
+		{"reordered", NewYAMLValue(testCompose), NewYAMLValue(reordered), true},
+		{"changed", NewYAMLValue(testCompose), NewYAMLValue(changed), false},
+		{"invalid yaml", NewYAMLValue(testCompose), NewYAMLValue("services: ["), false},
+		{"identical invalid yaml", NewYAMLValue("services: ["), NewYAMLValue("services: ["), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			equal, diags := tt.prior.StringSemanticEquals(context.Background(), tt.new)
+
+			assert.False(t, diags.HasError())
+			assert.Equal(t, tt.expected, equal)
+		})
+	}
+
+	_, diags := NewYAMLValue(testCompose).StringSemanticEquals(context.Background(), types.StringValue(testCompose))
+	assert.True(t, diags.HasError())
+}
+
+func TestYAMLTypeValueFromTerraform(t *testing.T) {
+	value, err := YAMLType{}.ValueFromTerraform(context.Background(), tftypes.NewValue(tftypes.String, testCompose))
+
+	assert.NoError(t, err)
+	assert.Equal(t, NewYAMLValue(testCompose), value)
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+
+	"terraform-provider-coolify/internal/api"
+)
+
+var _ function.Function = &validateUuidFunction{}
+
+func NewValidateUuidFunction() function.Function {
+	return &validateUuidFunction{}
+}
+
+type validateUuidFunction struct{}
+
+func (f *validateUuidFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "validate_uuid"
+}
+
+func (f *validateUuidFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Check whether a string is a Coolify UUID",
+		MarkdownDescription: "Returns `true` when the value is in the format Coolify uses for UUIDs: a CUID2 string of 7 to 32 lowercase letters and digits, e.g. `rg8ks8c` or `uoswco88w8swo40k48o8kcwk`. Coolify UUIDs are not RFC 4122 UUIDs, so values such as `123e4567-e89b-12d3-a456-426614174000` are rejected. Useful in variable validation blocks.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "uuid",
+				MarkdownDescription: "Value to check.",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *validateUuidFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var uuid string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &uuid))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, api.ValidateUuidFormat(uuid) == nil))
+}
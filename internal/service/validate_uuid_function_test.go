@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateUuidFunction(t *testing.T) {
+	tests := []struct {
+		name     string
+		uuid     string
+		expected attr.Value
+	}{
+		{name: "short", uuid: "rg8ks8c", expected: types.BoolValue(true)},
+		{name: "long", uuid: "uoswco88w8swo40k48o8kcwk", expected: types.BoolValue(true)},
+		{name: "rfc 4122", uuid: "123e4567-e89b-12d3-a456-426614174000", expected: types.BoolValue(false)},
+		{name: "uppercase", uuid: "RG8KS8C", expected: types.BoolValue(false)},
+		{name: "empty", uuid: "", expected: types.BoolValue(false)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(tt.uuid)}),
+			}
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.BoolUnknown()),
+			}
+
+			NewValidateUuidFunction().Run(context.Background(), req, resp)
+
+			assert.Nil(t, resp.Error)
+			assert.Equal(t, tt.expected, resp.Result.Value())
+		})
+	}
+}